@@ -0,0 +1,294 @@
+// Package abac is a small attribute-based policy engine evaluated after RBAC permission checks.
+// It has no DB or HTTP dependencies: callers build a Request from subject, resource and context
+// attributes and evaluate it against a set of Rules.
+package abac
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Effect is the outcome a matching rule imposes.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Condition operators.
+const (
+	OpEq          = "eq"
+	OpNe          = "ne"
+	OpIn          = "in"
+	OpNotIn       = "not_in"
+	OpContains    = "contains"
+	OpNotContains = "not_contains"
+	OpExists      = "exists"
+	OpNotExists   = "not_exists"
+	OpGt          = "gt"
+	OpGte         = "gte"
+	OpLt          = "lt"
+	OpLte         = "lte"
+)
+
+// Condition compares the attribute at Attr (dotted path such as "resource.owner_id") with either
+// a literal Value or the attribute at ValueAttr (e.g. "subject.id").
+type Condition struct {
+	Attr      string      `json:"attr"`
+	Op        string      `json:"op"`
+	Value     interface{} `json:"value,omitempty"`
+	ValueAttr string      `json:"value_attr,omitempty"`
+}
+
+// Rule applies Effect to requests whose action matches Action ("places:write", "places:*" or "*")
+// when every All condition holds and, if Any is non-empty, at least one Any condition holds.
+type Rule struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Action   string      `json:"action"`
+	Effect   Effect      `json:"effect"`
+	Priority int         `json:"priority"`
+	All      []Condition `json:"all,omitempty"`
+	Any      []Condition `json:"any,omitempty"`
+}
+
+// Request is the input to Evaluate.
+type Request struct {
+	Action   string                 `json:"action"`
+	Subject  map[string]interface{} `json:"subject"`
+	Resource map[string]interface{} `json:"resource"`
+	Context  map[string]interface{} `json:"context"`
+}
+
+// RuleTrace records how one rule was evaluated (for dry runs).
+type RuleTrace struct {
+	RuleID  string `json:"rule_id"`
+	Name    string `json:"name"`
+	Effect  Effect `json:"effect"`
+	Matched bool   `json:"matched"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Decision is the result of Evaluate. RuleID is empty when no rule matched (default allow).
+type Decision struct {
+	Allowed  bool        `json:"allowed"`
+	RuleID   string      `json:"rule_id,omitempty"`
+	RuleName string      `json:"rule_name,omitempty"`
+	Trace    []RuleTrace `json:"trace"`
+}
+
+// Evaluate returns the decision of the highest-priority matching rule; on equal priority deny wins.
+// If no rule matches the request is allowed, since RBAC has already granted the action.
+func Evaluate(rules []Rule, req Request) Decision {
+	applicable := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		if ActionMatches(r.Action, req.Action) {
+			applicable = append(applicable, r)
+		}
+	}
+	sort.SliceStable(applicable, func(i, j int) bool {
+		if applicable[i].Priority != applicable[j].Priority {
+			return applicable[i].Priority > applicable[j].Priority
+		}
+		return applicable[i].Effect == Deny && applicable[j].Effect != Deny
+	})
+	d := Decision{Allowed: true, Trace: make([]RuleTrace, 0, len(applicable))}
+	decided := false
+	for _, r := range applicable {
+		matched, reason := matchRule(r, req)
+		d.Trace = append(d.Trace, RuleTrace{RuleID: r.ID, Name: r.Name, Effect: r.Effect, Matched: matched, Reason: reason})
+		if matched && !decided {
+			decided = true
+			d.Allowed = r.Effect != Deny
+			d.RuleID = r.ID
+			d.RuleName = r.Name
+		}
+	}
+	return d
+}
+
+// ActionMatches reports whether pattern ("*", "places:*" or an exact key) covers action.
+func ActionMatches(pattern, action string) bool {
+	if pattern == "*" || pattern == action {
+		return true
+	}
+	if strings.HasSuffix(pattern, ":*") {
+		return strings.HasPrefix(action, strings.TrimSuffix(pattern, "*"))
+	}
+	return false
+}
+
+// Validate checks a rule's effect, action and operators.
+func Validate(r Rule) error {
+	if r.Effect != Allow && r.Effect != Deny {
+		return fmt.Errorf("effect must be allow or deny")
+	}
+	if r.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	for _, c := range append(append([]Condition{}, r.All...), r.Any...) {
+		if c.Attr == "" {
+			return fmt.Errorf("condition attr is required")
+		}
+		switch c.Op {
+		case OpEq, OpNe, OpIn, OpNotIn, OpContains, OpNotContains, OpExists, OpNotExists, OpGt, OpGte, OpLt, OpLte:
+		default:
+			return fmt.Errorf("unknown operator %q", c.Op)
+		}
+	}
+	return nil
+}
+
+func matchRule(r Rule, req Request) (bool, string) {
+	for _, c := range r.All {
+		if !evalCondition(c, req) {
+			return false, "condition failed: " + describe(c)
+		}
+	}
+	if len(r.Any) == 0 {
+		return true, ""
+	}
+	for _, c := range r.Any {
+		if evalCondition(c, req) {
+			return true, ""
+		}
+	}
+	return false, "no 'any' condition held"
+}
+
+func describe(c Condition) string {
+	if c.ValueAttr != "" {
+		return c.Attr + " " + c.Op + " " + c.ValueAttr
+	}
+	if c.Op == OpExists || c.Op == OpNotExists {
+		return c.Attr + " " + c.Op
+	}
+	return fmt.Sprintf("%s %s %v", c.Attr, c.Op, c.Value)
+}
+
+// Lookup resolves a dotted path ("subject.id", "resource.details.city") against the request.
+func Lookup(req Request, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	var cur interface{}
+	switch parts[0] {
+	case "subject":
+		cur = req.Subject
+	case "resource":
+		cur = req.Resource
+	case "context":
+		cur = req.Context
+	case "action":
+		return req.Action, len(parts) == 1
+	default:
+		return nil, false
+	}
+	for _, p := range parts[1:] {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[p]; !ok {
+			return nil, false
+		}
+	}
+	return cur, cur != nil
+}
+
+func evalCondition(c Condition, req Request) bool {
+	left, ok := Lookup(req, c.Attr)
+	switch c.Op {
+	case OpExists:
+		return ok
+	case OpNotExists:
+		return !ok
+	}
+	right := c.Value
+	if c.ValueAttr != "" {
+		var rok bool
+		if right, rok = Lookup(req, c.ValueAttr); !rok {
+			right = nil
+		}
+	}
+	switch c.Op {
+	case OpEq:
+		return ok && equal(left, right)
+	case OpNe:
+		return !ok || !equal(left, right)
+	case OpIn:
+		return ok && contains(right, left)
+	case OpNotIn:
+		return !ok || !contains(right, left)
+	case OpContains:
+		return ok && contains(left, right)
+	case OpNotContains:
+		return !ok || !contains(left, right)
+	case OpGt, OpGte, OpLt, OpLte:
+		l, lok := toFloat(left)
+		r, rok := toFloat(right)
+		if !ok || !lok || !rok {
+			return false
+		}
+		switch c.Op {
+		case OpGt:
+			return l > r
+		case OpGte:
+			return l >= r
+		case OpLt:
+			return l < r
+		default:
+			return l <= r
+		}
+	}
+	return false
+}
+
+// equal compares scalars loosely: numbers by value, everything else by string form.
+func equal(a, b interface{}) bool {
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return af == bf
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// contains reports whether list (a slice) holds item, or whether string list contains string item.
+func contains(list, item interface{}) bool {
+	if s, ok := list.(string); ok {
+		if sub, ok := item.(string); ok {
+			return strings.Contains(s, sub)
+		}
+		return false
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if equal(v.Index(i).Interface(), item) {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
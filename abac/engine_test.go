@@ -0,0 +1,199 @@
+package abac
+
+import "testing"
+
+func testRequest() Request {
+	return Request{
+		Action: "places:write",
+		Subject: map[string]interface{}{
+			"id":    "u1",
+			"city":  "Cairo",
+			"roles": []interface{}{"editor"},
+		},
+		Resource: map[string]interface{}{
+			"owner_id":    "u1",
+			"is_verified": false,
+			"details":     map[string]interface{}{"city": "Giza", "seats": float64(40)},
+		},
+		Context: map[string]interface{}{
+			"hour":   9,
+			"fields": []interface{}{"details", "is_verified"},
+			"path":   "/api/places/p1",
+		},
+	}
+}
+
+func TestActionMatches(t *testing.T) {
+	tests := []struct {
+		pattern, action string
+		want            bool
+	}{
+		{"*", "places:write", true},
+		{"places:write", "places:write", true},
+		{"places:*", "places:write", true},
+		{"places:*", "places:delete", true},
+		{"places:*", "place_types:write", false},
+		{"places:read", "places:write", false},
+		{"plans:*", "places:write", false},
+		{"places", "places:write", false},
+	}
+	for _, tt := range tests {
+		if got := ActionMatches(tt.pattern, tt.action); got != tt.want {
+			t.Errorf("ActionMatches(%q, %q) = %v, want %v", tt.pattern, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestConditionOperators(t *testing.T) {
+	tests := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"eq literal", Condition{Attr: "subject.city", Op: OpEq, Value: "Cairo"}, true},
+		{"eq attr", Condition{Attr: "resource.owner_id", Op: OpEq, ValueAttr: "subject.id"}, true},
+		{"eq nested attr", Condition{Attr: "resource.details.city", Op: OpEq, ValueAttr: "subject.city"}, false},
+		{"eq number loose", Condition{Attr: "context.hour", Op: OpEq, Value: float64(9)}, true},
+		{"eq missing", Condition{Attr: "subject.missing", Op: OpEq, Value: ""}, false},
+		{"ne", Condition{Attr: "resource.details.city", Op: OpNe, ValueAttr: "subject.city"}, true},
+		{"ne equal", Condition{Attr: "subject.city", Op: OpNe, Value: "Cairo"}, false},
+		{"ne missing", Condition{Attr: "subject.missing", Op: OpNe, Value: "x"}, true},
+		{"in", Condition{Attr: "subject.city", Op: OpIn, Value: []interface{}{"Alexandria", "Cairo"}}, true},
+		{"in absent", Condition{Attr: "subject.city", Op: OpIn, Value: []interface{}{"Alexandria"}}, false},
+		{"not_in", Condition{Attr: "subject.city", Op: OpNotIn, Value: []interface{}{"Alexandria"}}, true},
+		{"not_in present", Condition{Attr: "subject.city", Op: OpNotIn, Value: []interface{}{"Cairo"}}, false},
+		{"contains list", Condition{Attr: "context.fields", Op: OpContains, Value: "is_verified"}, true},
+		{"contains list absent", Condition{Attr: "context.fields", Op: OpContains, Value: "name"}, false},
+		{"contains string", Condition{Attr: "context.path", Op: OpContains, Value: "/places/"}, true},
+		{"contains roles", Condition{Attr: "subject.roles", Op: OpContains, Value: "editor"}, true},
+		{"not_contains", Condition{Attr: "context.fields", Op: OpNotContains, Value: "name"}, true},
+		{"not_contains present", Condition{Attr: "context.fields", Op: OpNotContains, Value: "details"}, false},
+		{"exists", Condition{Attr: "resource.details.seats", Op: OpExists}, true},
+		{"exists missing", Condition{Attr: "resource.details.wifi", Op: OpExists}, false},
+		{"not_exists", Condition{Attr: "resource.details.wifi", Op: OpNotExists}, true},
+		{"not_exists present", Condition{Attr: "resource.owner_id", Op: OpNotExists}, false},
+		{"gt", Condition{Attr: "resource.details.seats", Op: OpGt, Value: float64(39)}, true},
+		{"gt equal", Condition{Attr: "resource.details.seats", Op: OpGt, Value: float64(40)}, false},
+		{"gte", Condition{Attr: "resource.details.seats", Op: OpGte, Value: 40}, true},
+		{"lt", Condition{Attr: "context.hour", Op: OpLt, Value: "10"}, true},
+		{"lte", Condition{Attr: "context.hour", Op: OpLte, Value: 8}, false},
+		{"gt non-number", Condition{Attr: "subject.city", Op: OpGt, Value: 1}, false},
+		{"unknown operator", Condition{Attr: "subject.city", Op: "like", Value: "Cairo"}, false},
+	}
+	req := testRequest()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evalCondition(tt.cond, req); got != tt.want {
+				t.Errorf("evalCondition(%s) = %v, want %v", describe(tt.cond), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	ownerEditsVerified := Rule{
+		ID: "owner-verified", Name: "owners cannot set is_verified", Action: "places:write", Effect: Deny,
+		All: []Condition{
+			{Attr: "resource.owner_id", Op: OpEq, ValueAttr: "subject.id"},
+			{Attr: "context.fields", Op: OpContains, Value: "is_verified"},
+		},
+	}
+	editorOtherCity := Rule{
+		ID: "editor-city", Name: "editors stay in their city", Action: "places:*", Effect: Deny,
+		All: []Condition{
+			{Attr: "subject.roles", Op: OpContains, Value: "editor"},
+			{Attr: "resource.details.city", Op: OpNe, ValueAttr: "subject.city"},
+		},
+	}
+	tests := []struct {
+		name       string
+		rules      []Rule
+		action     string
+		wantAllow  bool
+		wantRuleID string
+		wantTraces int
+	}{
+		{"no rules allows", nil, "places:write", true, "", 0},
+		{"owner denied is_verified", []Rule{ownerEditsVerified}, "places:write", false, "owner-verified", 1},
+		{"editor denied outside city", []Rule{editorOtherCity}, "places:delete", false, "editor-city", 1},
+		{"other action ignored", []Rule{ownerEditsVerified}, "places:delete", true, "", 0},
+		{
+			"higher priority allow wins",
+			[]Rule{ownerEditsVerified, {ID: "admins", Action: "*", Effect: Allow, Priority: 10}},
+			"places:write", true, "admins", 2,
+		},
+		{
+			"deny wins equal priority",
+			[]Rule{{ID: "allow", Action: "*", Effect: Allow}, {ID: "deny", Action: "*", Effect: Deny}},
+			"places:write", false, "deny", 2,
+		},
+		{
+			"any needs one condition",
+			[]Rule{{ID: "any", Action: "*", Effect: Deny, Any: []Condition{
+				{Attr: "subject.city", Op: OpEq, Value: "Alexandria"},
+				{Attr: "context.hour", Op: OpLt, Value: 12},
+			}}},
+			"places:write", false, "any", 1,
+		},
+		{
+			"any with no holding condition",
+			[]Rule{{ID: "any", Action: "*", Effect: Deny, Any: []Condition{
+				{Attr: "subject.city", Op: OpEq, Value: "Alexandria"},
+			}}},
+			"places:write", true, "", 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testRequest()
+			req.Action = tt.action
+			d := Evaluate(tt.rules, req)
+			if d.Allowed != tt.wantAllow || d.RuleID != tt.wantRuleID || len(d.Trace) != tt.wantTraces {
+				t.Errorf("Evaluate = allowed %v rule %q traces %d, want %v %q %d",
+					d.Allowed, d.RuleID, len(d.Trace), tt.wantAllow, tt.wantRuleID, tt.wantTraces)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   interface{}
+		wantOK bool
+	}{
+		{"action", "places:write", true},
+		{"subject.id", "u1", true},
+		{"resource.details.city", "Giza", true},
+		{"resource.details.city.name", nil, false},
+		{"resource.is_verified", false, true},
+		{"context.missing", nil, false},
+		{"other.id", nil, false},
+	}
+	req := testRequest()
+	for _, tt := range tests {
+		got, ok := Lookup(req, tt.path)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Lookup(%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"valid", Rule{Action: "places:write", Effect: Deny, All: []Condition{{Attr: "subject.id", Op: OpExists}}}, false},
+		{"bad effect", Rule{Action: "places:write", Effect: "maybe"}, true},
+		{"missing action", Rule{Effect: Allow}, true},
+		{"missing attr", Rule{Action: "*", Effect: Allow, Any: []Condition{{Op: OpEq}}}, true},
+		{"unknown operator", Rule{Action: "*", Effect: Allow, All: []Condition{{Attr: "subject.id", Op: "like"}}}, true},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.rule); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
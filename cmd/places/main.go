@@ -0,0 +1,116 @@
+// Command places bulk imports places from CSV or GeoJSON, using the same validation as
+// POST /api/places/imports but processing the file synchronously.
+//
+//	go run ./cmd/places import -f cafes.csv -type cafe -actor admin@example.com [-map name=Title,details.wifi=WiFi] [-dry-run]
+//	go run ./cmd/places import -f cafes.geojson -type cafe -actor admin@example.com [-skip-duplicates]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ducksrow/backend/database"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "import" {
+		usage()
+	}
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("f", "", "file to import (.csv, .geojson or .json)")
+	format := fs.String("format", "", "csv or geojson (default from the file extension)")
+	placeType := fs.String("type", "", "place type slug or ID")
+	actor := fs.String("actor", "", "email of the user recorded as creator")
+	mapping := fs.String("map", "", "comma-separated target=column pairs, e.g. name=Title,details.wifi=WiFi")
+	dryRun := fs.Bool("dry-run", false, "validate only, create nothing")
+	skipDup := fs.Bool("skip-duplicates", false, "do not reject rows that look like existing places")
+	_ = fs.Parse(os.Args[2:])
+	if *file == "" || *placeType == "" || *actor == "" {
+		usage()
+	}
+
+	_ = godotenv.Load()
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatalf("database connect: %v", err)
+	}
+	ctx := context.Background()
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("read file: %v", err)
+	}
+	var user models.User
+	if err := db.Where("email = ?", *actor).First(&user).Error; err != nil {
+		log.Fatalf("actor %s: %v", *actor, err)
+	}
+	var pt models.PlaceType
+	q := db.Where("slug = ?", *placeType)
+	if id, err := uuid.Parse(*placeType); err == nil {
+		q = db.Where("id = ?", id)
+	}
+	if err := q.First(&pt).Error; err != nil {
+		log.Fatalf("place type %s: %v", *placeType, err)
+	}
+	in := services.ImportInput{
+		PlaceTypeID:        pt.ID,
+		Format:             *format,
+		DryRun:             *dryRun,
+		SkipDuplicateCheck: *skipDup,
+		Data:               data,
+	}
+	if in.Format == "" {
+		in.Format = models.ImportFormatCSV
+		if ext := strings.ToLower(filepath.Ext(*file)); ext == ".geojson" || ext == ".json" {
+			in.Format = models.ImportFormatGeoJSON
+		}
+	}
+	if *mapping != "" {
+		in.Mapping = map[string]string{}
+		for _, pair := range strings.Split(*mapping, ",") {
+			target, column, ok := strings.Cut(pair, "=")
+			if !ok {
+				log.Fatalf("-map: %q is not target=column", pair)
+			}
+			in.Mapping[strings.TrimSpace(target)] = strings.TrimSpace(column)
+		}
+	}
+
+	svc := services.NewPlaceImportService(db)
+	imp, err := svc.Create(ctx, user.ID, in)
+	if err != nil {
+		log.Fatalf("import: %v", err)
+	}
+	if err := svc.Run(ctx, imp.ID); err != nil {
+		log.Fatalf("import: %v", err)
+	}
+	if imp, err = svc.Get(ctx, user.ID, imp.ID); err != nil {
+		log.Fatalf("import: %v", err)
+	}
+	verb := "created"
+	if imp.DryRun {
+		verb = "valid"
+	}
+	fmt.Printf("import %s: %d rows, %d %s, %d failed\n", imp.ID, imp.TotalRows, imp.SucceededRows, verb, imp.FailedRows)
+	for _, e := range imp.Errors {
+		fmt.Printf("  row %d: %s\n", e.Row, e.Error)
+	}
+	if imp.FailedRows > len(imp.Errors) {
+		fmt.Printf("  ... %d more\n", imp.FailedRows-len(imp.Errors))
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: places import -f FILE -type SLUG|ID -actor EMAIL [-format csv|geojson] [-map target=column,...] [-dry-run] [-skip-duplicates]")
+	os.Exit(2)
+}
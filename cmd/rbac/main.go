@@ -0,0 +1,110 @@
+// Command rbac manages roles and permissions declaratively from a YAML or JSON policy file.
+//
+//	go run ./cmd/rbac plan   -f policy.yaml [-prune]
+//	go run ./cmd/rbac apply  -f policy.yaml -actor admin@example.com [-prune]
+//	go run ./cmd/rbac export [-format yaml|json] [-o policy.yaml]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"ducksrow/backend/database"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	cmd, args := os.Args[1], os.Args[2:]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	file := fs.String("f", "", "policy file (.yaml, .yml or .json)")
+	prune := fs.Bool("prune", false, "delete roles that are not in the policy file")
+	actor := fs.String("actor", "", "email of the user recorded in the audit log (apply)")
+	format := fs.String("format", "yaml", "export format: yaml or json")
+	out := fs.String("o", "", "export output file (default stdout)")
+	_ = fs.Parse(args)
+
+	_ = godotenv.Load()
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatalf("database connect: %v", err)
+	}
+	ctx := context.Background()
+	svc := services.NewRBACPolicyService(db)
+
+	switch cmd {
+	case "plan", "apply":
+		if *file == "" {
+			log.Fatal("-f is required")
+		}
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatalf("read policy: %v", err)
+		}
+		policy, err := services.ParsePolicy(*file, data)
+		if err != nil {
+			log.Fatalf("parse policy: %v", err)
+		}
+		if cmd == "plan" {
+			changes, err := svc.Plan(ctx, policy, *prune)
+			if err != nil {
+				log.Fatalf("plan: %v", err)
+			}
+			printChanges(changes)
+			return
+		}
+		if *actor == "" {
+			log.Fatal("-actor is required for apply")
+		}
+		var user models.User
+		if err := db.Where("email = ?", *actor).First(&user).Error; err != nil {
+			log.Fatalf("actor %s: %v", *actor, err)
+		}
+		changes, err := svc.Apply(ctx, user.ID, policy, *prune)
+		if err != nil {
+			log.Fatalf("apply: %v", err)
+		}
+		printChanges(changes)
+	case "export":
+		policy, err := svc.Export(ctx)
+		if err != nil {
+			log.Fatalf("export: %v", err)
+		}
+		data, err := services.EncodePolicy(policy, *format)
+		if err != nil {
+			log.Fatalf("encode: %v", err)
+		}
+		if *out == "" {
+			_, _ = os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(*out, data, 0o644); err != nil {
+			log.Fatalf("write: %v", err)
+		}
+	default:
+		usage()
+	}
+}
+
+func printChanges(changes []services.PolicyChange) {
+	if len(changes) == 0 {
+		fmt.Println("No changes. Roles match the policy.")
+		return
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: rbac plan|apply|export [flags]")
+	os.Exit(2)
+}
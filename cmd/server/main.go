@@ -1,16 +1,20 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"os"
 
 	"ducksrow/backend/database"
+	"ducksrow/backend/middleware"
 	"ducksrow/backend/routes"
+	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/joho/godotenv"
 )
 
@@ -43,17 +47,28 @@ func main() {
 	}
 
 	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{"error": err.Error()})
-		},
+		// Errors from handlers, middleware, unknown routes and recovered panics share one format
+		ErrorHandler: middleware.ErrorHandler,
 	})
+	// Room for place import uploads (multipart overhead included); other routes keep Fiber's 4 MB
+	middleware.RouteBodyLimits(app, map[string]int{
+		fiber.MethodPost + " /api/places/imports": services.MaxImportSize + 1<<20,
+	})
+
+	// Drop cached permissions when another instance changes roles (Postgres LISTEN/NOTIFY)
+	go services.ListenPermissionInvalidation(context.Background(), database.DSN())
+	// Drop cached map tiles when places change on any instance
+	go services.ListenPlaceTileInvalidation(context.Background(), database.DSN())
+	// Build queued data exports, purge expired ones and erase accounts past their grace period
+	go services.RunPrivacyWorker(context.Background(), db)
+	// Start imports left pending by a restart and fail the ones it interrupted
+	go services.RunPlaceImportWorker(context.Background(), db)
+	// Refresh the admin dashboard rollups
+	go services.RunStatsWorker(context.Background(), db)
 
-	app.Use(recover.New())
-	app.Use(logger.New())
+	app.Use(requestid.New())
+	app.Use(recover.New(recover.Config{EnableStackTrace: true}))
+	app.Use(logger.New(logger.Config{Format: "${time} | ${locals:requestid} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${error}\n"}))
 
 	routes.Setup(app, db)
 
@@ -13,17 +13,21 @@ import (
 
 // Connect opens a connection to PostgreSQL and returns a GORM DB instance.
 func Connect() (*gorm.DB, error) {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = buildDSN()
-	}
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(DSN()), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("gorm open: %w", err)
 	}
 	return db, nil
 }
 
+// DSN returns DATABASE_URL, or a DSN built from the DB_* variables when it is unset.
+func DSN() string {
+	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
+		return dsn
+	}
+	return buildDSN()
+}
+
 func buildDSN() string {
 	host := getEnv("DB_HOST", "localhost")
 	port := getEnv("DB_PORT", "5432")
@@ -52,6 +56,14 @@ func Migrate(db *gorm.DB) error {
 	if err := models.MigrateAll(db); err != nil {
 		return fmt.Errorf("migrate models: %w", err)
 	}
+	// Places verified before the verification workflow existed keep their status.
+	if err := db.Exec("UPDATE places SET verification_status = 'verified' WHERE is_verified AND verification_status = 'unverified'").Error; err != nil {
+		return fmt.Errorf("backfill place verification status: %w", err)
+	}
+	// Role definition changes were logged with a zero target user before the column became nullable.
+	if err := db.Exec("UPDATE role_audit_logs SET target_user_id = NULL WHERE target_user_id = '00000000-0000-0000-0000-000000000000'").Error; err != nil {
+		return fmt.Errorf("backfill role audit target users: %w", err)
+	}
 	return nil
 }
 
@@ -34,9 +34,10 @@ func SeedRBAC(db *gorm.DB) error {
 		return err
 	}
 	editorPerms := []string{
-		permissions.PlacesRead, permissions.PlacesWrite,
+		permissions.PlacesRead, permissions.PlacesWrite, permissions.PlacesVerify, permissions.PlacesImport,
 		permissions.PlaceTypesRead, permissions.PlaceTypesWrite,
 		permissions.PlansRead, permissions.PlansWrite,
+		permissions.ReviewsRead, permissions.ReviewsWrite, permissions.ReviewsDelete,
 		permissions.UsersRead,
 	}
 	if err := ensureRolePermissions(db, editor.ID, editorPerms); err != nil {
@@ -45,12 +46,14 @@ func SeedRBAC(db *gorm.DB) error {
 	clientPerms := []string{
 		permissions.PlacesRead, permissions.PlaceTypesRead,
 		permissions.PlansRead, permissions.PlansWrite, permissions.PlansDelete,
+		permissions.ReviewsRead, permissions.ReviewsWrite,
 	}
 	if err := ensureRolePermissions(db, client.ID, clientPerms); err != nil {
 		return err
 	}
 	ownerPerms := []string{
 		permissions.PlacesRead, permissions.PlacesOwn, permissions.PlaceTypesRead,
+		permissions.ReviewsRead, permissions.ReviewsWrite,
 	}
 	if err := ensureRolePermissions(db, owner.ID, ownerPerms); err != nil {
 		return err
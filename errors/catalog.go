@@ -0,0 +1,484 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Message keys for request errors raised by handlers and services (see Invalid and Msg). Templates use
+// {field} and other {placeholders} filled from the error's params.
+const (
+	MsgInvalidID      = "invalid_id"
+	MsgInvalidBody    = "invalid_body"
+	MsgRequired       = "required"
+	MsgInvalidValue   = "invalid_value"
+	MsgFormat         = "format"
+	MsgOneOf          = "one_of"
+	MsgTooLarge       = "too_large"
+	MsgDuplicatePlace = "duplicate_place"
+	MsgBannedWord     = "banned_word"
+	MsgNotPlaceOwner  = "not_place_owner"
+	MsgAdminTarget    = "admin_target"
+
+	MsgLength           = "length"
+	MsgMaxLength        = "max_length"
+	MsgRange            = "range"
+	MsgMaxItems         = "max_items"
+	MsgMaxSize          = "max_size"
+	MsgURL              = "url"
+	MsgNotEmpty         = "not_empty"
+	MsgString           = "string"
+	MsgNumber           = "number"
+	MsgStringList       = "string_list"
+	MsgObject           = "object"
+	MsgFutureDate       = "future_date"
+	MsgPastDate         = "past_date"
+	MsgNotAfter         = "not_after"
+	MsgMaxDays          = "max_days"
+	MsgMinAge           = "min_age"
+	MsgMaxAge           = "max_age"
+	MsgUsernameFormat   = "username_format"
+	MsgNotEditable      = "not_editable"
+	MsgNotTranslatable  = "not_translatable"
+	MsgNotInSchema      = "not_in_schema"
+	MsgUnknown          = "unknown"
+	MsgColumnNotFound   = "column_not_found"
+	MsgNoChanges        = "no_changes"
+	MsgRequiredToReject = "required_to_reject"
+	MsgOneRequired      = "one_required"
+	MsgExclusive        = "exclusive"
+	MsgSelfTarget       = "self_target"
+	MsgOwnContent       = "own_content"
+	MsgSameAs           = "same_as"
+	MsgNoAuthor         = "no_author"
+	MsgTimezone         = "timezone"
+	MsgOSMRule          = "osm_rule"
+	MsgOSMSpan          = "osm_span"
+	MsgInterval         = "interval"
+	MsgDuplicateValue   = "duplicate_value"
+	MsgInvalidReason    = "invalid_reason"
+	MsgRoleDefinition   = "role_definition"
+	MsgRolePermissions  = "role_permissions"
+
+	MsgAlreadyOwner        = "already_owner"
+	MsgClaimPending        = "claim_pending"
+	MsgUserOwnsPlace       = "user_owns_place"
+	MsgUsernameCooldown    = "username_cooldown_until"
+	MsgTaken               = "taken"
+	MsgAlreadyVerified     = "already_verified"
+	MsgVerificationPending = "verification_pending"
+	MsgPlaceChanged        = "place_changed"
+	MsgDownloadLinkInvalid = "download_link_invalid"
+	MsgImpersonateAdmin    = "impersonate_admin"
+	MsgPlaceMerged         = "place_merged"
+
+	MsgReviewOwnPlace     = "review_own_place"
+	MsgAlreadyReviewed    = "already_reviewed"
+	MsgReviewEditAuthor   = "review_edit_author"
+	MsgReviewDeleteAuthor = "review_delete_author"
+	MsgReplyNotOwner      = "reply_not_owner"
+
+	MsgAlreadyReported = "already_reported"
+
+	MsgMissingToken      = "missing_token"
+	MsgInvalidAuthFormat = "invalid_auth_format"
+	MsgInvalidToken      = "invalid_token"
+	MsgImpersonating     = "impersonating"
+)
+
+// sourceLocale is the language of the sentinel texts and of wrapped error details.
+const sourceLocale = "en"
+
+// messages holds the message templates per locale, keyed by machine code (the generic message
+// for that code), sentinel key or Msg* key.
+var messages = map[string]map[string]string{
+	"en": {
+		"VALIDATION_ERROR":   "invalid request",
+		"NOT_FOUND":          "not found",
+		"UNAUTHORIZED":       "authentication required",
+		"FORBIDDEN":          "forbidden",
+		"CONFLICT":           "conflict",
+		"UNPROCESSABLE":      "request cannot be processed",
+		"METHOD_NOT_ALLOWED": "method not allowed",
+		"PAYLOAD_TOO_LARGE":  "request body is too large",
+		"TOO_MANY_REQUESTS":  "too many requests",
+		"INTERNAL_ERROR":     "internal server error",
+
+		"role_not_found":        "role not found",
+		"user_not_found":        "user not found",
+		"assignment_not_found":  "user role assignment not found",
+		"permission_invalid":    "permission not in catalog",
+		"system_role_protected": "system role cannot be deleted or have permissions reduced",
+		"role_slug_conflict":    "role slug already exists",
+		"role_name_conflict":    "role name already exists",
+		"forbidden":             "insufficient permissions",
+		"validation":            "validation error",
+		"conflict":              "resource already exists",
+		"unauthorized":          "invalid credentials",
+		"last_admin":            "at least one active admin must remain",
+		"roles_manage_lockout":  "change would leave no user able to manage roles",
+		"self_lockout":          "cannot remove your own ability to manage roles",
+		"grant_not_found":       "role grant request not found",
+		"grant_not_pending":     "role grant request is no longer pending",
+		"grant_expired":         "role grant request has expired",
+		"self_approval":         "role grant must be decided by an admin other than the requester and the grantee",
+		"policy_not_found":      "access policy not found",
+		"policy_denied":         "denied by access policy",
+		"account_suspended":     "account suspended",
+		"username_cooldown":     "username was changed too recently",
+		"verification_invalid":  "verification token is invalid or expired",
+		"export_not_found":      "data export not found",
+		"place_not_found":       "place not found",
+		"request_not_found":     "request not found",
+		"request_not_pending":   "request is no longer pending",
+		"self_review":           "cannot review your own request",
+		"revision_not_found":    "revision not found",
+		"import_not_found":      "place import not found",
+		"opening_hours_not_set": "place has no opening hours",
+		"place_type_not_found":  "place type not found",
+		"not_authenticated":     "user not authenticated",
+		"review_not_found":      "review not found",
+		"plan_not_found":        "plan not found",
+
+		MsgInvalidID:      "invalid {field}",
+		MsgInvalidBody:    "invalid request body",
+		MsgRequired:       "{field} is required",
+		MsgInvalidValue:   "invalid {field}",
+		MsgFormat:         "{field} must be in the format {format}",
+		MsgOneOf:          "{field} must be {values}",
+		MsgTooLarge:       "{field} is too large",
+		MsgDuplicatePlace: "similar places already exist nearby; resend with force=true to create anyway",
+		MsgBannedWord:     "{field} contains a word that is not allowed",
+		MsgNotPlaceOwner:  "only the place owner or an admin can do this",
+		MsgAdminTarget:    "only an admin can change an admin account",
+
+		MsgLength:           "{field} must be {min}-{max} characters",
+		MsgMaxLength:        "{field} must be at most {max} characters",
+		MsgRange:            "{field} must be between {min} and {max}",
+		MsgMaxItems:         "{field} accepts at most {max} items",
+		MsgMaxSize:          "{field} must be at most {max} MB",
+		MsgURL:              "{field} must use http(s) URLs",
+		MsgNotEmpty:         "{field} must not be empty",
+		MsgString:           "{field} must be a string",
+		MsgNumber:           "{field} must be a number",
+		MsgStringList:       "{field} must be a list of strings",
+		MsgObject:           "{field} must be a non-empty object",
+		MsgFutureDate:       "{field} cannot be in the future",
+		MsgPastDate:         "{field} must be in the future",
+		MsgNotAfter:         "{field} must not be after {other}",
+		MsgMaxDays:          "{field} is limited to {max} days",
+		MsgMinAge:           "you must be at least {age} years old",
+		MsgMaxAge:           "{field} is more than {age} years ago",
+		MsgUsernameFormat:   "{field} must be 3-32 letters, digits, '_', '.' or '-'",
+		MsgNotEditable:      "{field} cannot be edited",
+		MsgNotTranslatable:  "{field} cannot be translated",
+		MsgNotInSchema:      "{field} is not in the place type's form schema",
+		MsgUnknown:          "unknown {field} \"{value}\"",
+		MsgColumnNotFound:   "{field}: column \"{column}\" not found",
+		MsgNoChanges:        "{field} does not change anything",
+		MsgRequiredToReject: "{field} is required when rejecting",
+		MsgOneRequired:      "{field} or {other} is required",
+		MsgExclusive:        "give either {field} or {other}, not both",
+		MsgSelfTarget:       "you cannot do this to your own account",
+		MsgOwnContent:       "you cannot report your own content",
+		MsgSameAs:           "{field} must differ from {other}",
+		MsgNoAuthor:         "this content has no author to {value}",
+		MsgTimezone:         "{field} must be an IANA time zone name such as Europe/Paris",
+		MsgOSMRule:          "{field}: rule \"{rule}\" is invalid or unsupported",
+		MsgOSMSpan:          "{field}: invalid time span \"{span}\" in rule \"{rule}\"",
+		MsgInterval:         "{field}: invalid interval {opens}-{closes}",
+		MsgDuplicateValue:   "{field} {value} appears more than once",
+		MsgInvalidReason:    "invalid {field}: {reason}",
+		MsgRoleDefinition:   "{field}: role \"{slug}\" needs a valid slug and a name",
+		MsgRolePermissions:  "{field}: new role \"{slug}\" needs at least one permission",
+
+		MsgAlreadyOwner:        "you already own this place",
+		MsgClaimPending:        "you already have a pending claim on this place",
+		MsgUserOwnsPlace:       "user already owns this place",
+		MsgUsernameCooldown:    "username was changed too recently; next change allowed after {until}",
+		MsgTaken:               "{field} is taken",
+		MsgAlreadyVerified:     "place is already verified",
+		MsgVerificationPending: "place has a pending verification request",
+		MsgPlaceChanged:        "place changed since the suggestion ({fields})",
+		MsgDownloadLinkInvalid: "download link is invalid or expired",
+		MsgImpersonateAdmin:    "cannot impersonate an admin",
+		MsgPlaceMerged:         "place was merged into {place_id}",
+
+		MsgReviewOwnPlace:     "owners cannot review their own place",
+		MsgAlreadyReviewed:    "you have already reviewed this place",
+		MsgReviewEditAuthor:   "only the author can edit a review",
+		MsgReviewDeleteAuthor: "only the author can delete a review",
+		MsgReplyNotOwner:      "only the place owner can reply to reviews",
+
+		MsgAlreadyReported: "you have already reported this",
+
+		MsgMissingToken:      "missing authorization header",
+		MsgInvalidAuthFormat: "invalid authorization format",
+		MsgInvalidToken:      "invalid or expired token",
+		MsgImpersonating:     "not allowed while impersonating",
+	},
+	"ar": {
+		"VALIDATION_ERROR":   "الطلب غير صالح",
+		"NOT_FOUND":          "غير موجود",
+		"UNAUTHORIZED":       "يلزم تسجيل الدخول",
+		"FORBIDDEN":          "غير مسموح",
+		"CONFLICT":           "تعارض مع الحالة الحالية",
+		"UNPROCESSABLE":      "تعذّرت معالجة الطلب",
+		"METHOD_NOT_ALLOWED": "الطريقة غير مسموح بها",
+		"PAYLOAD_TOO_LARGE":  "محتوى الطلب كبير جدًا",
+		"TOO_MANY_REQUESTS":  "طلبات كثيرة جدًا",
+		"INTERNAL_ERROR":     "خطأ داخلي في الخادم",
+
+		"role_not_found":        "الدور غير موجود",
+		"user_not_found":        "المستخدم غير موجود",
+		"assignment_not_found":  "إسناد الدور للمستخدم غير موجود",
+		"permission_invalid":    "الصلاحية غير موجودة في القائمة",
+		"system_role_protected": "لا يمكن حذف دور النظام أو تقليص صلاحياته",
+		"role_slug_conflict":    "المعرّف النصي للدور مستخدم مسبقًا",
+		"role_name_conflict":    "اسم الدور مستخدم مسبقًا",
+		"forbidden":             "صلاحيات غير كافية",
+		"validation":            "خطأ في التحقق من البيانات",
+		"conflict":              "المورد موجود مسبقًا",
+		"unauthorized":          "بيانات الدخول غير صحيحة",
+		"last_admin":            "يجب أن يبقى مسؤول نشط واحد على الأقل",
+		"roles_manage_lockout":  "هذا التغيير سيترك النظام دون مستخدم قادر على إدارة الأدوار",
+		"self_lockout":          "لا يمكنك إزالة قدرتك على إدارة الأدوار",
+		"grant_not_found":       "طلب منح الدور غير موجود",
+		"grant_not_pending":     "طلب منح الدور لم يعد قيد الانتظار",
+		"grant_expired":         "انتهت صلاحية طلب منح الدور",
+		"self_approval":         "يجب أن يبتّ في منح الدور مسؤول غير مقدّم الطلب والمستفيد",
+		"policy_not_found":      "سياسة الوصول غير موجودة",
+		"policy_denied":         "مرفوض بموجب سياسة الوصول",
+		"account_suspended":     "الحساب موقوف",
+		"username_cooldown":     "تم تغيير اسم المستخدم مؤخرًا",
+		"verification_invalid":  "رمز التحقق غير صالح أو منتهي الصلاحية",
+		"export_not_found":      "تصدير البيانات غير موجود",
+		"place_not_found":       "المكان غير موجود",
+		"request_not_found":     "الطلب غير موجود",
+		"request_not_pending":   "الطلب لم يعد قيد الانتظار",
+		"self_review":           "لا يمكنك مراجعة طلبك بنفسك",
+		"revision_not_found":    "النسخة غير موجودة",
+		"import_not_found":      "عملية الاستيراد غير موجودة",
+		"opening_hours_not_set": "لا توجد ساعات عمل لهذا المكان",
+		"place_type_not_found":  "نوع المكان غير موجود",
+		"not_authenticated":     "المستخدم غير مسجّل الدخول",
+		"review_not_found":      "المراجعة غير موجودة",
+		"plan_not_found":        "الخطة غير موجودة",
+
+		MsgInvalidID:      "قيمة {field} غير صالحة",
+		MsgInvalidBody:    "محتوى الطلب غير صالح",
+		MsgRequired:       "الحقل {field} مطلوب",
+		MsgInvalidValue:   "قيمة {field} غير صالحة",
+		MsgFormat:         "يجب أن يكون {field} بالصيغة {format}",
+		MsgOneOf:          "يجب أن تكون قيمة {field} إحدى: {values}",
+		MsgTooLarge:       "{field} كبير جدًا",
+		MsgDuplicatePlace: "توجد أماكن مشابهة قريبة؛ أعد الإرسال مع force=true للإنشاء على أي حال",
+		MsgBannedWord:     "يحتوي {field} على كلمة غير مسموح بها",
+		MsgNotPlaceOwner:  "يمكن لمالك المكان أو المسؤول فقط القيام بذلك",
+		MsgAdminTarget:    "يمكن للمسؤول فقط تعديل حساب مسؤول",
+
+		MsgLength:           "يجب أن يتكون {field} من {min} إلى {max} حرفًا",
+		MsgMaxLength:        "يجب ألا يتجاوز {field} {max} حرفًا",
+		MsgRange:            "يجب أن تكون قيمة {field} بين {min} و{max}",
+		MsgMaxItems:         "يقبل {field} {max} عناصر كحد أقصى",
+		MsgMaxSize:          "يجب ألا يتجاوز حجم {field} {max} ميغابايت",
+		MsgURL:              "يجب أن يستخدم {field} روابط http(s)",
+		MsgNotEmpty:         "يجب ألا يكون {field} فارغًا",
+		MsgString:           "يجب أن يكون {field} نصًا",
+		MsgNumber:           "يجب أن يكون {field} رقمًا",
+		MsgStringList:       "يجب أن يكون {field} قائمة نصوص",
+		MsgObject:           "يجب أن يكون {field} كائنًا غير فارغ",
+		MsgFutureDate:       "لا يمكن أن يكون {field} في المستقبل",
+		MsgPastDate:         "يجب أن يكون {field} في المستقبل",
+		MsgNotAfter:         "يجب ألا يكون {field} بعد {other}",
+		MsgMaxDays:          "يقتصر {field} على {max} يومًا",
+		MsgMinAge:           "يجب أن يكون عمرك {age} سنة على الأقل",
+		MsgMaxAge:           "يعود {field} إلى أكثر من {age} سنة",
+		MsgUsernameFormat:   "يجب أن يتكون {field} من 3 إلى 32 حرفًا أو رقمًا أو '_' أو '.' أو '-'",
+		MsgNotEditable:      "لا يمكن تعديل {field}",
+		MsgNotTranslatable:  "لا يمكن ترجمة {field}",
+		MsgNotInSchema:      "{field} غير موجود في نموذج نوع المكان",
+		MsgUnknown:          "قيمة {field} غير معروفة: \"{value}\"",
+		MsgColumnNotFound:   "{field}: العمود \"{column}\" غير موجود",
+		MsgNoChanges:        "لا يغيّر {field} أي شيء",
+		MsgRequiredToReject: "الحقل {field} مطلوب عند الرفض",
+		MsgOneRequired:      "يلزم إدخال {field} أو {other}",
+		MsgExclusive:        "أدخل {field} أو {other} وليس كليهما",
+		MsgSelfTarget:       "لا يمكنك تنفيذ هذا الإجراء على حسابك",
+		MsgOwnContent:       "لا يمكنك الإبلاغ عن محتواك",
+		MsgSameAs:           "يجب أن يختلف {field} عن {other}",
+		MsgNoAuthor:         "لا يوجد كاتب لهذا المحتوى لتطبيق الإجراء {value}",
+		MsgTimezone:         "يجب أن يكون {field} اسم منطقة زمنية IANA مثل Europe/Paris",
+		MsgOSMRule:          "{field}: القاعدة \"{rule}\" غير صالحة أو غير مدعومة",
+		MsgOSMSpan:          "{field}: الفترة الزمنية \"{span}\" غير صالحة في القاعدة \"{rule}\"",
+		MsgInterval:         "{field}: الفترة {opens}-{closes} غير صالحة",
+		MsgDuplicateValue:   "تتكرر قيمة {field} {value} أكثر من مرة",
+		MsgInvalidReason:    "قيمة {field} غير صالحة: {reason}",
+		MsgRoleDefinition:   "{field}: يحتاج الدور \"{slug}\" إلى معرّف نصي صالح واسم",
+		MsgRolePermissions:  "{field}: يحتاج الدور الجديد \"{slug}\" إلى صلاحية واحدة على الأقل",
+
+		MsgAlreadyOwner:        "أنت مالك هذا المكان بالفعل",
+		MsgClaimPending:        "لديك طلب مطالبة قيد الانتظار لهذا المكان",
+		MsgUserOwnsPlace:       "المستخدم مالك هذا المكان بالفعل",
+		MsgUsernameCooldown:    "تم تغيير اسم المستخدم مؤخرًا؛ التغيير التالي مسموح بعد {until}",
+		MsgTaken:               "قيمة {field} مستخدمة مسبقًا",
+		MsgAlreadyVerified:     "المكان موثّق بالفعل",
+		MsgVerificationPending: "للمكان طلب توثيق قيد الانتظار",
+		MsgPlaceChanged:        "تغيّر المكان منذ تقديم الاقتراح ({fields})",
+		MsgDownloadLinkInvalid: "رابط التنزيل غير صالح أو منتهي الصلاحية",
+		MsgImpersonateAdmin:    "لا يمكن انتحال هوية مسؤول",
+		MsgPlaceMerged:         "تم دمج المكان في {place_id}",
+
+		MsgReviewOwnPlace:     "لا يمكن للمالك تقييم مكانه",
+		MsgAlreadyReviewed:    "لقد قيّمت هذا المكان بالفعل",
+		MsgReviewEditAuthor:   "يمكن لكاتب المراجعة فقط تعديلها",
+		MsgReviewDeleteAuthor: "يمكن لكاتب المراجعة فقط حذفها",
+		MsgReplyNotOwner:      "يمكن لمالك المكان فقط الرد على المراجعات",
+
+		MsgAlreadyReported: "لقد أبلغت عن هذا المحتوى بالفعل",
+
+		MsgMissingToken:      "ترويسة التفويض مفقودة",
+		MsgInvalidAuthFormat: "صيغة التفويض غير صالحة",
+		MsgInvalidToken:      "الرمز غير صالح أو منتهي الصلاحية",
+		MsgImpersonating:     "غير مسموح أثناء انتحال هوية مستخدم",
+	},
+}
+
+// sentinelKeys maps each sentinel error to its message key.
+var sentinelKeys = []struct {
+	err error
+	key string
+}{
+	{ErrRoleNotFound, "role_not_found"},
+	{ErrUserNotFound, "user_not_found"},
+	{ErrAssignmentNotFound, "assignment_not_found"},
+	{ErrPermissionInvalid, "permission_invalid"},
+	{ErrSystemRoleProtected, "system_role_protected"},
+	{ErrRoleSlugConflict, "role_slug_conflict"},
+	{ErrRoleNameConflict, "role_name_conflict"},
+	{ErrForbidden, "forbidden"},
+	{ErrValidation, "validation"},
+	{ErrConflict, "conflict"},
+	{ErrUnauthorized, "unauthorized"},
+	{ErrLastAdmin, "last_admin"},
+	{ErrRolesManageLockout, "roles_manage_lockout"},
+	{ErrSelfLockout, "self_lockout"},
+	{ErrGrantNotFound, "grant_not_found"},
+	{ErrGrantNotPending, "grant_not_pending"},
+	{ErrGrantExpired, "grant_expired"},
+	{ErrSelfApproval, "self_approval"},
+	{ErrPolicyNotFound, "policy_not_found"},
+	{ErrPolicyDenied, "policy_denied"},
+	{ErrAccountSuspended, "account_suspended"},
+	{ErrUsernameCooldown, "username_cooldown"},
+	{ErrVerificationInvalid, "verification_invalid"},
+	{ErrExportNotFound, "export_not_found"},
+	{ErrPlaceNotFound, "place_not_found"},
+	{ErrRequestNotFound, "request_not_found"},
+	{ErrRequestNotPending, "request_not_pending"},
+	{ErrSelfReview, "self_review"},
+	{ErrRevisionNotFound, "revision_not_found"},
+	{ErrImportNotFound, "import_not_found"},
+	{ErrOpeningHoursNotSet, "opening_hours_not_set"},
+	{ErrPlaceTypeNotFound, "place_type_not_found"},
+	{ErrNotAuthenticated, "not_authenticated"},
+	{ErrReviewNotFound, "review_not_found"},
+	{ErrPlanNotFound, "plan_not_found"},
+}
+
+// FieldError is a validation failure on one request field. Code is the message key.
+type FieldError struct {
+	Field   string            `json:"field"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Params  map[string]string `json:"-"`
+}
+
+// Error is an error with a catalogue message: Key selects the template and Params fill it. Kind
+// is the sentinel that decides the HTTP status and code; Fields lists field-level failures.
+type Error struct {
+	Kind   error
+	Key    string
+	Params map[string]string
+	Fields []FieldError
+}
+
+func (e *Error) Error() string { return Translate([]string{sourceLocale}, e.Key, e.Params) }
+
+// Unwrap returns the sentinel.
+func (e *Error) Unwrap() error { return e.Kind }
+
+// Msg returns an error of the given kind with the message key and params (name, value pairs).
+func Msg(kind error, key string, params ...string) *Error {
+	return &Error{Kind: kind, Key: key, Params: pairs(params)}
+}
+
+// Invalid returns a validation error on one request field, e.g. Invalid("id", MsgInvalidID).
+// The field name is available to the template as {field}.
+func Invalid(field, key string, params ...string) *Error {
+	p := pairs(params)
+	p["field"] = field
+	return &Error{Kind: ErrValidation, Key: key, Params: p, Fields: []FieldError{{Field: field, Code: key, Params: p}}}
+}
+
+func pairs(params []string) map[string]string {
+	p := make(map[string]string, len(params)/2+1)
+	for i := 0; i+1 < len(params); i += 2 {
+		p[params[i]] = params[i+1]
+	}
+	return p
+}
+
+// Translate renders the message template for key in the first locale of the chain that has
+// it, falling back to English; unknown keys render as the key itself.
+func Translate(locales []string, key string, params map[string]string) string {
+	tpl, _ := lookup(locales, key)
+	for k, v := range params {
+		tpl = strings.ReplaceAll(tpl, "{"+k+"}", v)
+	}
+	return tpl
+}
+
+func lookup(locales []string, key string) (string, string) {
+	for _, l := range locales {
+		if tpl, ok := messages[l][key]; ok {
+			return tpl, l
+		}
+	}
+	if tpl, ok := messages[sourceLocale][key]; ok {
+		return tpl, sourceLocale
+	}
+	return key, sourceLocale
+}
+
+// Localize returns the message for err in the first locale of the chain that has one, the
+// untranslated detail of a wrapped error ("validation error: name is required") when the
+// message had to be replaced by its sentinel's, and the localized field errors.
+// Unknown (internal) errors get the generic INTERNAL_ERROR message.
+func Localize(err error, locales []string) (message, detail string, fields []FieldError) {
+	var e *Error
+	if errors.As(err, &e) {
+		fields = make([]FieldError, len(e.Fields))
+		for i, f := range e.Fields {
+			f.Message = Translate(locales, f.Code, f.Params)
+			fields[i] = f
+		}
+		return Translate(locales, e.Key, e.Params), "", fields
+	}
+	for _, s := range sentinelKeys {
+		if !errors.Is(err, s.err) {
+			continue
+		}
+		if err.Error() == s.err.Error() {
+			return Translate(locales, s.key, nil), "", nil
+		}
+		// Wrapped with a detail, which only exists in English.
+		tpl, locale := lookup(locales, s.key)
+		if locale == sourceLocale {
+			return err.Error(), "", nil
+		}
+		return tpl, err.Error(), nil
+	}
+	_, code := HTTPStatusAndCode(err)
+	return Translate(locales, code, nil), "", nil
+}
@@ -15,6 +15,30 @@ var (
 	ErrValidation          = errors.New("validation error")
 	ErrConflict            = errors.New("resource already exists")
 	ErrUnauthorized        = errors.New("invalid credentials")
+	ErrLastAdmin           = errors.New("at least one active admin must remain")
+	ErrRolesManageLockout  = errors.New("change would leave no user able to manage roles")
+	ErrSelfLockout         = errors.New("cannot remove your own ability to manage roles")
+	ErrGrantNotFound       = errors.New("role grant request not found")
+	ErrGrantNotPending     = errors.New("role grant request is no longer pending")
+	ErrGrantExpired        = errors.New("role grant request has expired")
+	ErrSelfApproval        = errors.New("role grant must be approved by a different admin")
+	ErrPolicyNotFound      = errors.New("access policy not found")
+	ErrPolicyDenied        = errors.New("denied by access policy")
+	ErrAccountSuspended    = errors.New("account suspended")
+	ErrUsernameCooldown    = errors.New("username was changed too recently")
+	ErrVerificationInvalid = errors.New("verification token is invalid or expired")
+	ErrExportNotFound      = errors.New("data export not found")
+	ErrPlaceNotFound       = errors.New("place not found")
+	ErrRequestNotFound     = errors.New("request not found")
+	ErrRequestNotPending   = errors.New("request is no longer pending")
+	ErrSelfReview          = errors.New("cannot review your own request")
+	ErrRevisionNotFound    = errors.New("revision not found")
+	ErrImportNotFound      = errors.New("place import not found")
+	ErrOpeningHoursNotSet  = errors.New("place has no opening hours")
+	ErrPlaceTypeNotFound   = errors.New("place type not found")
+	ErrNotAuthenticated    = errors.New("user not authenticated")
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrPlanNotFound        = errors.New("plan not found")
 )
 
 // HTTPStatusAndCode returns (statusCode, machineCode) for the standard error envelope.
@@ -24,17 +48,26 @@ func HTTPStatusAndCode(err error) (int, string) {
 		return 500, "INTERNAL_ERROR"
 	}
 	switch {
-	case errors.Is(err, ErrRoleNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrAssignmentNotFound):
+	case errors.Is(err, ErrRoleNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrAssignmentNotFound),
+		errors.Is(err, ErrGrantNotFound), errors.Is(err, ErrPolicyNotFound), errors.Is(err, ErrExportNotFound),
+		errors.Is(err, ErrPlaceNotFound), errors.Is(err, ErrRequestNotFound), errors.Is(err, ErrRevisionNotFound),
+		errors.Is(err, ErrImportNotFound), errors.Is(err, ErrOpeningHoursNotSet),
+		errors.Is(err, ErrPlaceTypeNotFound), errors.Is(err, ErrReviewNotFound),
+		errors.Is(err, ErrPlanNotFound):
 		return 404, "NOT_FOUND"
-	case errors.Is(err, ErrValidation):
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrVerificationInvalid):
 		return 400, "VALIDATION_ERROR"
-	case errors.Is(err, ErrUnauthorized):
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrNotAuthenticated):
 		return 401, "UNAUTHORIZED"
 	case errors.Is(err, ErrPermissionInvalid):
 		return 422, "UNPROCESSABLE"
-	case errors.Is(err, ErrSystemRoleProtected), errors.Is(err, ErrForbidden):
+	case errors.Is(err, ErrSystemRoleProtected), errors.Is(err, ErrForbidden), errors.Is(err, ErrSelfApproval),
+		errors.Is(err, ErrPolicyDenied), errors.Is(err, ErrAccountSuspended), errors.Is(err, ErrSelfReview):
 		return 403, "FORBIDDEN"
-	case errors.Is(err, ErrRoleSlugConflict), errors.Is(err, ErrRoleNameConflict), errors.Is(err, ErrConflict):
+	case errors.Is(err, ErrRoleSlugConflict), errors.Is(err, ErrRoleNameConflict), errors.Is(err, ErrConflict),
+		errors.Is(err, ErrLastAdmin), errors.Is(err, ErrRolesManageLockout), errors.Is(err, ErrSelfLockout),
+		errors.Is(err, ErrGrantNotPending), errors.Is(err, ErrGrantExpired), errors.Is(err, ErrUsernameCooldown),
+		errors.Is(err, ErrRequestNotPending):
 		return 409, "CONFLICT"
 	default:
 		return 500, "INTERNAL_ERROR"
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListAccessPolicies returns GET /api/policies.
+func ListAccessPolicies(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		list, err := svc.List(c.Context())
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": list})
+	}
+}
+
+// GetAccessPolicy returns GET /api/policies/:id.
+func GetAccessPolicy(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		p, err := svc.Get(c.Context(), id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": p})
+	}
+}
+
+// CreateAccessPolicy handles POST /api/policies.
+func CreateAccessPolicy(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		var req services.AccessPolicyInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		p, err := svc.Create(c.Context(), req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": p})
+	}
+}
+
+// UpdateAccessPolicy handles PUT /api/policies/:id.
+func UpdateAccessPolicy(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		var req services.AccessPolicyInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		p, err := svc.Update(c.Context(), id, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": p})
+	}
+}
+
+// DeleteAccessPolicy handles DELETE /api/policies/:id.
+func DeleteAccessPolicy(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		if err := svc.Delete(c.Context(), id); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// EvaluateAccessPolicies handles POST /api/policies/evaluate — dry-run of a hypothetical request.
+// Returns the evaluated request (with loaded attributes), the decision and a per-rule trace.
+func EvaluateAccessPolicies(db *gorm.DB) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		var req services.DryRunInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		evaluated, decision, err := svc.DryRun(c.Context(), req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": fiber.Map{"request": evaluated, "decision": decision}})
+	}
+}
@@ -1,10 +1,80 @@
 package handlers
 
 import (
+	"time"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-// AdminStats returns a simple message for testing admin access.
-func AdminStats(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Welcome Admin"})
+// AdminStats returns GET /admin/stats?from=YYYY-MM-DD&to=YYYY-MM-DD&bucket=day|week — dashboard
+// aggregates from the rollup tables. Defaults: the last 30 days, daily buckets.
+func AdminStats(db *gorm.DB) fiber.Handler {
+	svc := services.NewStatsService(db)
+	return func(c *fiber.Ctx) error {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		to, err := parseStatsDate(c.Query("to"), today)
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("to", rbacerrors.MsgFormat, "format", "YYYY-MM-DD"))
+		}
+		from, err := parseStatsDate(c.Query("from"), to.AddDate(0, 0, -29))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("from", rbacerrors.MsgFormat, "format", "YYYY-MM-DD"))
+		}
+		stats, err := svc.Stats(c.Context(), from, to, c.Query("bucket", services.StatsBucketDay))
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": stats})
+	}
+}
+
+func parseStatsDate(s string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// PermissionCacheStats returns GET /admin/metrics/permission-cache — hit / miss counters of the permission cache.
+func PermissionCacheStats(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"data": services.DefaultPermissionCache().Stats()})
+}
+
+// Impersonate handles POST /admin/users/:id/impersonate — issues a short-lived token acting as the user.
+// The token carries the admin as impersonator_id; admin routes and sensitive actions reject it.
+// No MFA exists yet; gate this endpoint on it once it does.
+func Impersonate(db *gorm.DB, secret string) fiber.Handler {
+	svc := services.NewImpersonationService(db)
+	return func(c *fiber.Ctx) error {
+		targetID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		adminID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		user, err := svc.Start(c.Context(), adminID, targetID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		expiresAt := time.Now().Add(services.ImpersonationTTL())
+		token, err := issueImpersonationJWT(secret, user.ID.String(), user.Email, adminID.String(), expiresAt)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"data": fiber.Map{
+				"token":           token,
+				"expires_at":      expiresAt.UTC().Format("2006-01-02T15:04:05.000Z"),
+				"impersonator_id": adminID,
+				"user":            user,
+			},
+		})
+	}
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"strconv"
 
+	rbacerrors "ducksrow/backend/errors"
 	"ducksrow/backend/models"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,9 +16,12 @@ type AuditEntryDTO struct {
 	ID         uuid.UUID    `json:"id"`
 	Actor      ActorTarget  `json:"actor"`
 	Action     string       `json:"action"`
-	TargetUser ActorTarget  `json:"target_user"`
+	TargetUser *ActorTarget `json:"target_user"` // null for role definition changes
 	Role       AuditRoleDTO `json:"role"`
-	CreatedAt  string       `json:"created_at"`
+	Detail     string       `json:"detail,omitempty"`
+	// Impersonator is the real actor when Actor was acting under an impersonation token.
+	Impersonator *ActorTarget `json:"impersonator,omitempty"`
+	CreatedAt    string       `json:"created_at"`
 }
 
 // ActorTarget is { id, name } for actor or target_user.
@@ -55,47 +59,39 @@ func ListRoleAudit(db *gorm.DB) fiber.Handler {
 		if userIDStr != "" {
 			uid, err := uuid.Parse(userIDStr)
 			if err != nil {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "invalid user_id",
-					"code":  "VALIDATION_ERROR",
-				})
+				return RespondError(c, rbacerrors.Invalid("user_id", rbacerrors.MsgInvalidID))
 			}
 			q = q.Where("target_user_id = ?", uid)
 		}
 		if roleIDStr != "" {
 			rid, err := uuid.Parse(roleIDStr)
 			if err != nil {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "invalid role_id",
-					"code":  "VALIDATION_ERROR",
-				})
+				return RespondError(c, rbacerrors.Invalid("role_id", rbacerrors.MsgInvalidID))
 			}
 			q = q.Where("role_id = ?", rid)
 		}
 		if action != "" {
-			if action != models.AuditActionAssign && action != models.AuditActionRemove {
-				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-					"error": "action must be assign or remove",
-					"code":  "VALIDATION_ERROR",
-				})
+			switch action {
+			case models.AuditActionAssign, models.AuditActionRemove,
+				models.AuditActionRequest, models.AuditActionApprove, models.AuditActionReject,
+				models.AuditActionRoleCreate, models.AuditActionRoleUpdate, models.AuditActionRoleDelete,
+				models.AuditActionImpersonate, models.AuditActionSuspend, models.AuditActionUnsuspend,
+				models.AuditActionUserDelete, models.AuditActionUserRestore,
+				models.AuditActionErasure, models.AuditActionUserErase:
+			default:
+				return RespondError(c, rbacerrors.Invalid("action", rbacerrors.MsgInvalidValue))
 			}
 			q = q.Where("action = ?", action)
 		}
 
 		var total int64
 		if err := q.Count(&total).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to count audit log",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		offset := (page - 1) * limit
 		var logs []models.RoleAuditLog
 		if err := q.Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to list audit log",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 
 		// Load actor and target user names, and role names
@@ -104,7 +100,12 @@ func ListRoleAudit(db *gorm.DB) fiber.Handler {
 		roleIDs := make(map[uuid.UUID]bool)
 		for _, l := range logs {
 			actorIDs[l.ActorID] = true
-			targetIDs[l.TargetUserID] = true
+			if l.ImpersonatorID != nil {
+				actorIDs[*l.ImpersonatorID] = true
+			}
+			if l.TargetUserID != nil {
+				targetIDs[*l.TargetUserID] = true
+			}
 			roleIDs[l.RoleID] = true
 		}
 		users := make(map[uuid.UUID]string) // id -> name
@@ -140,17 +141,23 @@ func ListRoleAudit(db *gorm.DB) fiber.Handler {
 		data := make([]AuditEntryDTO, len(logs))
 		for i, l := range logs {
 			data[i] = AuditEntryDTO{
-				ID:         l.ID,
-				Action:     l.Action,
-				Actor:      ActorTarget{ID: l.ActorID, Name: users[l.ActorID]},
-				TargetUser: ActorTarget{ID: l.TargetUserID, Name: users[l.TargetUserID]},
+				ID:     l.ID,
+				Action: l.Action,
+				Actor:  ActorTarget{ID: l.ActorID, Name: users[l.ActorID]},
 				Role: AuditRoleDTO{
 					ID:   l.RoleID,
 					Slug: l.RoleSlug,
 					Name: roles[l.RoleID],
 				},
+				Detail:    l.Detail,
 				CreatedAt: l.CreatedAt.UTC().Format("2006-01-02T15:04:05.000Z"),
 			}
+			if l.TargetUserID != nil {
+				data[i].TargetUser = &ActorTarget{ID: *l.TargetUserID, Name: users[*l.TargetUserID]}
+			}
+			if l.ImpersonatorID != nil {
+				data[i].Impersonator = &ActorTarget{ID: *l.ImpersonatorID, Name: users[*l.ImpersonatorID]}
+			}
 		}
 		return c.JSON(fiber.Map{
 			"data": data,
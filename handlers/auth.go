@@ -48,14 +48,16 @@ func Register(svc authService, secret string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var req RegisterRequest
 		if err := c.BodyParser(&req); err != nil {
-			return RespondError(c, rbacerrors.ErrValidation)
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
 		}
-		if req.Username == "" || req.Email == "" || req.Password == "" {
-			return RespondError(c, rbacerrors.ErrValidation)
+		for _, f := range []struct{ name, value string }{{"username", req.Username}, {"email", req.Email}, {"password", req.Password}} {
+			if f.value == "" {
+				return RespondError(c, rbacerrors.Invalid(f.name, rbacerrors.MsgRequired))
+			}
 		}
 		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to hash password", "code": "INTERNAL_ERROR"})
+			return RespondError(c, err)
 		}
 		user, roles, err := svc.RegisterUser(c.Context(), req.Username, req.Email, string(hash))
 		if err != nil {
@@ -63,7 +65,7 @@ func Register(svc authService, secret string) fiber.Handler {
 		}
 		token, err := issueJWT(secret, user.ID.String(), user.Email)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token", "code": "INTERNAL_ERROR"})
+			return RespondError(c, err)
 		}
 		return c.Status(fiber.StatusCreated).JSON(AuthResponse{Token: token, User: user, Roles: roles})
 	}
@@ -74,10 +76,13 @@ func Login(svc authService, secret string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var req LoginRequest
 		if err := c.BodyParser(&req); err != nil {
-			return RespondError(c, rbacerrors.ErrValidation)
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
 		}
-		if req.Email == "" || req.Password == "" {
-			return RespondError(c, rbacerrors.ErrValidation)
+		if req.Email == "" {
+			return RespondError(c, rbacerrors.Invalid("email", rbacerrors.MsgRequired))
+		}
+		if req.Password == "" {
+			return RespondError(c, rbacerrors.Invalid("password", rbacerrors.MsgRequired))
 		}
 		user, roles, err := svc.AuthenticateUser(c.Context(), req.Email, req.Password)
 		if err != nil {
@@ -85,7 +90,7 @@ func Login(svc authService, secret string) fiber.Handler {
 		}
 		token, err := issueJWT(secret, user.ID.String(), user.Email)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token", "code": "INTERNAL_ERROR"})
+			return RespondError(c, err)
 		}
 		return c.JSON(AuthResponse{Token: token, User: user, Roles: roles})
 	}
@@ -110,3 +115,18 @@ func issueJWT(secret string, userID string, email string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
+
+// issueImpersonationJWT signs a short-lived token for userID that also carries the impersonating admin.
+func issueImpersonationJWT(secret string, userID, email, impersonatorID string, expiresAt time.Time) (string, error) {
+	claims := &middleware.JWTClaims{
+		UserID:         userID,
+		Email:          email,
+		ImpersonatorID: impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
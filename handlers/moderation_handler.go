@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportContent handles POST /api/reports — any user reports a review, place or public plan (201).
+func ReportContent(db *gorm.DB) fiber.Handler {
+	svc := services.NewModerationService(db)
+	return func(c *fiber.Ctx) error {
+		reporterID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.ReportInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		report, err := svc.Report(c.Context(), reporterID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": report})
+	}
+}
+
+// ModerationQueue returns GET /api/moderation/queue?target_type= — reported content, most reported first.
+// Excerpts quote the stored text that was reported and are not localized.
+func ModerationQueue(db *gorm.DB) fiber.Handler {
+	svc := services.NewModerationService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.Queue(c.Context(), c.Query("target_type"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ListContentReports returns GET /api/moderation/reports?status=&target_type=&target_id=.
+func ListContentReports(db *gorm.DB) fiber.Handler {
+	svc := services.NewModerationService(db)
+	return func(c *fiber.Ctx) error {
+		targetID, err := optionalUUIDQuery(c, "target_id")
+		if err != nil {
+			return RespondError(c, err)
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.Reports(c.Context(), c.Query("status"), c.Query("target_type"), targetID, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ModerateContent handles POST /api/moderation/targets/:type/:id/actions — applies an action
+// and closes the target's open reports (201 with the audit entry).
+func ModerateContent(db *gorm.DB) fiber.Handler {
+	svc := services.NewModerationService(db)
+	return func(c *fiber.Ctx) error {
+		targetID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.ModerationActionInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		action, err := svc.Act(c.Context(), actorID, c.Params("type"), targetID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": action})
+	}
+}
+
+// ListModerationActions returns GET /api/moderation/actions?target_type=&target_id=&user_id= —
+// the moderation audit trail.
+func ListModerationActions(db *gorm.DB) fiber.Handler {
+	svc := services.NewModerationService(db)
+	return func(c *fiber.Ctx) error {
+		targetID, err := optionalUUIDQuery(c, "target_id")
+		if err != nil {
+			return RespondError(c, err)
+		}
+		userID, err := optionalUUIDQuery(c, "user_id")
+		if err != nil {
+			return RespondError(c, err)
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.Actions(c.Context(), c.Query("target_type"), targetID, userID, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// optionalUUIDQuery parses an optional UUID query parameter.
+func optionalUUIDQuery(c *fiber.Ctx, name string) (*uuid.UUID, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, rbacerrors.Invalid(name, rbacerrors.MsgInvalidID)
+	}
+	return &id, nil
+}
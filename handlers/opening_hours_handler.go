@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetOpeningHours returns GET /api/places/:id/opening-hours — the weekly schedule, date
+// exceptions, timezone, the OSM opening_hours form and whether the place is open now.
+func GetOpeningHours(db *gorm.DB) fiber.Handler {
+	svc := services.NewOpeningHoursService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		hours, err := svc.Get(c.Context(), placeID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": hours})
+	}
+}
+
+// SetOpeningHours handles PUT /api/places/:id/opening-hours. Body: {"timezone", "weekly",
+// "exceptions"} or {"timezone", "osm"} with an OSM opening_hours string.
+func SetOpeningHours(db *gorm.DB) fiber.Handler {
+	svc := services.NewOpeningHoursService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		var req services.OpeningHoursInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		hours, err := svc.Set(c.Context(), &actorID, placeID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": hours})
+	}
+}
+
+// DeleteOpeningHours handles DELETE /api/places/:id/opening-hours.
+func DeleteOpeningHours(db *gorm.DB) fiber.Handler {
+	svc := services.NewOpeningHoursService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		if err := svc.Delete(c.Context(), &actorID, placeID); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -1,7 +1,17 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
 	"ducksrow/backend/models"
+	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -11,25 +21,51 @@ import (
 // CreatePlaceRequest is the JSON body for creating a place (includes JSONB details).
 type CreatePlaceRequest struct {
 	Name        string                 `json:"name"`
+	Name_local  string                 `json:"name_local"`
 	Description string                 `json:"description"`
 	Address     string                 `json:"address"`
 	Lat         float64                `json:"lat"`
 	Lon         float64                `json:"lon"`
 	Details     map[string]interface{} `json:"details"`
 	PlaceTypeID string                 `json:"place_type_id"` // UUID string
-	IsVerified  bool                   `json:"is_verified"`
+	Force       bool                   `json:"force"`         // create even if similar places exist nearby
 }
 
-// CreatePlace creates a new place with dynamic Details (JSONB).
+// CreatePlace creates a new place with dynamic Details (JSONB). Places start unverified;
+// use POST /api/places/:id/verification to request verification. If places with a similar name
+// exist nearby it returns 409 DUPLICATE_PLACE with the candidates unless force is true.
 func CreatePlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceService(db)
+	dupSvc := services.NewPlaceDuplicateService(db)
+	translations := services.NewTranslationService(db)
 	return func(c *fiber.Ctx) error {
 		var req CreatePlaceRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		if req.Name == "" {
+			return RespondError(c, rbacerrors.Invalid("name", rbacerrors.MsgRequired))
 		}
 		placeTypeID, err := uuid.Parse(req.PlaceTypeID)
-		if err != nil || req.Name == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "name and valid place_type_id (UUID) required"})
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("place_type_id", rbacerrors.MsgInvalidID))
+		}
+		if !req.Force {
+			candidates, err := dupSvc.Candidates(c.Context(), req.Name, req.Name_local, req.Lat, req.Lon)
+			if err != nil {
+				return RespondError(c, err)
+			}
+			if len(candidates) > 0 {
+				places := make([]*models.Place, len(candidates))
+				for i := range candidates {
+					places[i] = &candidates[i].Place
+				}
+				if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), places...); err != nil {
+					return RespondError(c, err)
+				}
+				return middleware.RespondErrorWith(c, rbacerrors.Msg(rbacerrors.ErrConflict, rbacerrors.MsgDuplicatePlace), 0,
+					fiber.Map{"code": "DUPLICATE_PLACE", "candidates": candidates})
+			}
 		}
 		var ownerID *uuid.UUID
 		if uid, ok := c.Locals("userID").(uuid.UUID); ok && uid != uuid.Nil {
@@ -37,6 +73,7 @@ func CreatePlace(db *gorm.DB) fiber.Handler {
 		}
 		place := models.Place{
 			Name:        req.Name,
+			Name_local:  req.Name_local,
 			Description: req.Description,
 			Address:     req.Address,
 			Latitude:    req.Lat,
@@ -44,11 +81,178 @@ func CreatePlace(db *gorm.DB) fiber.Handler {
 			Details:     models.DetailsJSON(req.Details),
 			PlaceTypeID: placeTypeID,
 			OwnerID:     ownerID,
-			IsVerified:  req.IsVerified,
 		}
-		if err := db.Create(&place).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if err := svc.Create(c.Context(), ownerID, &place); err != nil {
+			return RespondError(c, err)
 		}
 		return c.Status(fiber.StatusCreated).JSON(place)
 	}
 }
+
+// ListPlaces returns GET /api/places — paginated places, newest first. Filters: place_type (slug
+// or ID), q (name search), verification_status, owner_id, bbox=min_lon,min_lat,max_lon,max_lat,
+// open_now=true or open_at=<RFC 3339 time> (places whose opening hours cover that instant).
+// Names and descriptions are localized (Accept-Language).
+func ListPlaces(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		filter, ok := placeFilterFromQuery(c)
+		if !ok {
+			return nil
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), filter, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		places := make([]*models.Place, len(list))
+		for i := range list {
+			places[i] = &list[i]
+		}
+		if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), places...); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ExportPlaces streams GET /api/places/export?format=geojson|csv|kml with the ListPlaces filters.
+// Details are flattened into columns / properties named after the place type's form fields.
+// Names and descriptions are the stored values, not localized, so an export can be re-imported.
+func ExportPlaces(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceExportService(db)
+	return func(c *fiber.Ctx) error {
+		filter, ok := placeFilterFromQuery(c)
+		if !ok {
+			return nil
+		}
+		exp, err := svc.Prepare(c.Context(), filter, strings.ToLower(c.Query("format", services.ExportFormatGeoJSON)))
+		if err != nil {
+			return RespondError(c, err)
+		}
+		c.Set(fiber.HeaderContentType, exp.ContentType)
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="places.`+exp.Extension+`"`)
+		// The body is written after the handler returns, so the export cannot use the request context.
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := exp.WriteTo(context.Background(), w); err != nil {
+				log.Printf("place export: %v", err)
+			}
+		})
+		return nil
+	}
+}
+
+// placeFilterFromQuery reads the place listing filters. On failure it writes the error response
+// and returns ok=false.
+func placeFilterFromQuery(c *fiber.Ctx) (services.PlaceFilter, bool) {
+	f := services.PlaceFilter{
+		PlaceType:          c.Query("place_type"),
+		Query:              c.Query("q"),
+		VerificationStatus: c.Query("verification_status"),
+	}
+	if raw := c.Query("owner_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			_ = RespondError(c, rbacerrors.Invalid("owner_id", rbacerrors.MsgInvalidID))
+			return f, false
+		}
+		f.OwnerID = &id
+	}
+	if raw := c.Query("bbox"); raw != "" {
+		parts := strings.Split(raw, ",")
+		f.BBox = make([]float64, 0, len(parts))
+		for _, part := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				_ = RespondError(c, rbacerrors.Invalid("bbox", rbacerrors.MsgFormat, "format", "min_lon,min_lat,max_lon,max_lat"))
+				return f, false
+			}
+			f.BBox = append(f.BBox, v)
+		}
+	}
+	if raw := c.Query("open_at"); raw != "" {
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			_ = RespondError(c, rbacerrors.Invalid("open_at", rbacerrors.MsgFormat, "format", "RFC 3339"))
+			return f, false
+		}
+		f.OpenAt = &at
+	} else if c.QueryBool("open_now") {
+		now := time.Now()
+		f.OpenAt = &now
+	}
+	return f, true
+}
+
+// GetPlace returns GET /api/places/:id. A place that was merged into another one answers
+// 301 with the surviving place's URL. Name, description and place type are localized (Accept-Language).
+func GetPlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		place, mergedInto, err := svc.Get(c.Context(), placeID)
+		if mergedInto != nil {
+			return c.Redirect("/api/places/"+mergedInto.String(), fiber.StatusMovedPermanently)
+		}
+		if err != nil {
+			return RespondError(c, err)
+		}
+		if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), place); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": place})
+	}
+}
+
+// UpdatePlace handles PATCH /api/places/:id. The body is a partial object of editable fields
+// (name, name_local, description, address, latitude, longitude, details); a null details key removes it.
+func UpdatePlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var changes map[string]interface{}
+		if err := c.BodyParser(&changes); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		place, err := svc.Update(c.Context(), actorID, placeID, changes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": place})
+	}
+}
+
+// DeletePlace handles DELETE /api/places/:id (soft delete, 204). Restoring a revision undeletes it.
+func DeletePlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		if err := svc.Delete(c.Context(), actorID, placeID); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransferPlaceRequest is the body for POST /api/places/:id/transfer.
+type TransferPlaceRequest struct {
+	To    string `json:"to"` // user UUID or email
+	Notes string `json:"notes"`
+}
+
+// ClaimPlace handles POST /api/places/:id/claims — files an ownership claim with proof (201).
+func ClaimPlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		claimantID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.ClaimInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		claim, err := svc.Claim(c.Context(), claimantID, placeID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": claim})
+	}
+}
+
+// ListPlaceClaims returns GET /api/places/claims?status=pending — the admin review queue. Place
+// names are localized (Accept-Language).
+func ListPlaceClaims(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), c.Query("status"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		places := make([]*models.Place, 0, len(list))
+		for i := range list {
+			if list[i].Place != nil {
+				places = append(places, list[i].Place)
+			}
+		}
+		if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), places...); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ApprovePlaceClaim handles POST /api/places/claims/:id/approve.
+func ApprovePlaceClaim(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	return func(c *fiber.Ctx) error {
+		id, reviewerID, req, ok := parseReviewDecision(c, "id")
+		if !ok {
+			return nil
+		}
+		claim, err := svc.Approve(c.Context(), reviewerID, id, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": claim})
+	}
+}
+
+// RejectPlaceClaim handles POST /api/places/claims/:id/reject.
+func RejectPlaceClaim(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	return func(c *fiber.Ctx) error {
+		id, reviewerID, req, ok := parseReviewDecision(c, "id")
+		if !ok {
+			return nil
+		}
+		claim, err := svc.Reject(c.Context(), reviewerID, id, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": claim})
+	}
+}
+
+// TransferPlace handles POST /api/places/:id/transfer — hands ownership to another user (204).
+func TransferPlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req TransferPlaceRequest
+		if err := c.BodyParser(&req); err != nil || req.To == "" {
+			return RespondError(c, rbacerrors.Invalid("to", rbacerrors.MsgRequired))
+		}
+		if err := svc.Transfer(c.Context(), actorID, placeID, req.To, req.Notes); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// ListPlaceOwnership returns GET /api/places/:id/ownership — ownership changes, newest first.
+func ListPlaceOwnership(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceClaimService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		list, err := svc.Ownership(c.Context(), placeID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": list})
+	}
+}
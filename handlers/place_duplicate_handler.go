@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MergePlaceRequest is the body of POST /api/places/:id/merge.
+type MergePlaceRequest struct {
+	Into string `json:"into"` // surviving place ID
+}
+
+// ListPlaceDuplicates returns GET /api/places/duplicates — pairs of places with similar names
+// close to each other, most similar first. Names are localized (Accept-Language); the similarity
+// is computed on the stored names.
+func ListPlaceDuplicates(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceDuplicateService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.Report(c.Context(), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		places := make([]*models.Place, 0, 2*len(list))
+		for i := range list {
+			places = append(places, &list[i].A, &list[i].B)
+		}
+		if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), places...); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// MergePlace handles POST /api/places/:id/merge — merges place :id into the place given in
+// "into" and returns the surviving place.
+func MergePlace(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceDuplicateService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req MergePlaceRequest
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		survivorID, err := uuid.Parse(req.Into)
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("into", rbacerrors.MsgInvalidID))
+		}
+		place, err := svc.Merge(c.Context(), actorID, placeID, survivorID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": place})
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportPlaces handles POST /api/places/imports (multipart/form-data) and returns the queued
+// import (202). Fields: file, place_type_id, format (csv|geojson; default from the file
+// extension), mapping (JSON object target -> column), dry_run, skip_duplicate_check.
+func ImportPlaces(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceImportService(db)
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("file", rbacerrors.MsgRequired))
+		}
+		if fh.Size > services.MaxImportSize {
+			return middleware.RespondErrorWith(c, rbacerrors.Invalid("file", rbacerrors.MsgTooLarge), fiber.StatusRequestEntityTooLarge, nil)
+		}
+		placeTypeID, err := uuid.Parse(c.FormValue("place_type_id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("place_type_id", rbacerrors.MsgInvalidID))
+		}
+		in := services.ImportInput{
+			PlaceTypeID:        placeTypeID,
+			Format:             strings.ToLower(c.FormValue("format")),
+			DryRun:             formBool(c, "dry_run"),
+			SkipDuplicateCheck: formBool(c, "skip_duplicate_check"),
+		}
+		if in.Format == "" {
+			in.Format = importFormatFromName(fh.Filename)
+		}
+		if raw := c.FormValue("mapping"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &in.Mapping); err != nil {
+				return RespondError(c, rbacerrors.Invalid("mapping", rbacerrors.MsgFormat, "format", `{"target": "column"}`))
+			}
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return RespondError(c, err)
+		}
+		defer f.Close()
+		if in.Data, err = io.ReadAll(f); err != nil {
+			return RespondError(c, err)
+		}
+		imp, err := svc.Submit(c.Context(), userID, in)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"data": imp})
+	}
+}
+
+// ListPlaceImports returns GET /api/places/imports — the caller's imports, newest first.
+func ListPlaceImports(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceImportService(db)
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), userID, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// GetPlaceImport returns GET /api/places/imports/:id — progress and per-row error report.
+func GetPlaceImport(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceImportService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		imp, err := svc.Get(c.Context(), userID, id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": imp})
+	}
+}
+
+func formBool(c *fiber.Ctx, key string) bool {
+	b, _ := strconv.ParseBool(c.FormValue(key))
+	return b
+}
+
+func importFormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return models.ImportFormatCSV
+	case ".geojson", ".json":
+		return models.ImportFormatGeoJSON
+	}
+	return ""
+}
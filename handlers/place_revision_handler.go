@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListPlaceRevisions returns GET /api/places/:id/revisions — newest first, each with the
+// field-level changes against the previous revision.
+func ListPlaceRevisions(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceRevisionService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		list, err := svc.List(c.Context(), placeID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": list})
+	}
+}
+
+// RestorePlaceRevision handles POST /api/places/:id/revisions/:rev/restore — writes the revision's
+// content back to the place (recorded as a new revision) and returns the place.
+func RestorePlaceRevision(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceRevisionService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		number, err := strconv.Atoi(c.Params("rev"))
+		if err != nil || number < 1 {
+			return RespondError(c, rbacerrors.Invalid("rev", rbacerrors.MsgInvalidValue))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		place, err := svc.Restore(c.Context(), actorID, placeID, number)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": place})
+	}
+}
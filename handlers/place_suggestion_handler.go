@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SuggestPlaceEdit handles POST /api/places/:id/suggestions — proposes a diff to the place (201).
+func SuggestPlaceEdit(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceSuggestionService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		authorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.SuggestionInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		sug, err := svc.Suggest(c.Context(), authorID, placeID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": sug})
+	}
+}
+
+// ListPlaceSuggestions returns GET /api/places/suggestions (all places) or
+// GET /api/places/:id/suggestions (one place); filter with ?status=pending. Changes and base
+// values are not localized: they are compared with the stored place fields on accept.
+func ListPlaceSuggestions(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceSuggestionService(db)
+	return func(c *fiber.Ctx) error {
+		var placeID *uuid.UUID
+		if raw := c.Params("id"); raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+			}
+			placeID = &id
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), placeID, c.Query("status"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// AcceptPlaceSuggestion handles POST /api/places/:id/suggestions/:sid/accept.
+// Returns 409 if the place changed since the suggestion was made.
+func AcceptPlaceSuggestion(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceSuggestionService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		sid, reviewerID, req, ok := parseReviewDecision(c, "sid")
+		if !ok {
+			return nil
+		}
+		sug, err := svc.Accept(c.Context(), reviewerID, placeID, sid, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": sug})
+	}
+}
+
+// RejectPlaceSuggestion handles POST /api/places/:id/suggestions/:sid/reject.
+func RejectPlaceSuggestion(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceSuggestionService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		sid, reviewerID, req, ok := parseReviewDecision(c, "sid")
+		if !ok {
+			return nil
+		}
+		sug, err := svc.Reject(c.Context(), reviewerID, placeID, sid, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": sug})
+	}
+}
+
+// TopContributors returns GET /api/contributors?limit= — users ranked by reputation.
+func TopContributors(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceSuggestionService(db)
+	return func(c *fiber.Ctx) error {
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, err := svc.TopContributors(c.Context(), limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": list})
+	}
+}
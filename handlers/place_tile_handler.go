@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// PlaceTile returns GET /api/tiles/places/:z/:x/:y.mvt — a Mapbox Vector Tile with a "places"
+// layer, clustered (point_count) at low zoom. Filters: place_type (slug or ID), verification_status.
+// An empty tile answers 204. Names are the stored name / name_local, not localized: tiles are
+// built in PostGIS and cached for every client regardless of Accept-Language.
+func PlaceTile(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceTileService(db)
+	return func(c *fiber.Ctx) error {
+		z, errZ := strconv.Atoi(c.Params("z"))
+		x, errX := strconv.Atoi(c.Params("x"))
+		y, errY := strconv.Atoi(c.Params("y"))
+		if errZ != nil || errX != nil || errY != nil {
+			return RespondError(c, rbacerrors.Invalid("z/x/y", rbacerrors.MsgInvalidValue))
+		}
+		tile, err := svc.Tile(c.Context(), z, x, y, services.PlaceFilter{
+			PlaceType:          c.Query("place_type"),
+			VerificationStatus: c.Query("verification_status"),
+		})
+		if err != nil {
+			return RespondError(c, err)
+		}
+		if len(tile) == 0 {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+		c.Set(fiber.HeaderContentType, "application/vnd.mapbox-vector-tile")
+		return c.Send(tile)
+	}
+}
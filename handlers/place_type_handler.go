@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListPlaceTypes returns GET /api/place-types with names and form field labels localized
+// (Accept-Language).
+func ListPlaceTypes(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceTypeService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		list, err := svc.List(c.Context())
+		if err != nil {
+			return RespondError(c, err)
+		}
+		types := make([]*models.PlaceType, len(list))
+		for i := range list {
+			types[i] = &list[i]
+		}
+		if err := translations.LocalizePlaceTypes(c.Context(), middleware.Locales(c), types...); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": list})
+	}
+}
+
+// GetPlaceType returns GET /api/place-types/:id, localized like ListPlaceTypes.
+func GetPlaceType(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceTypeService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		pt, err := svc.Get(c.Context(), id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		if err := translations.LocalizePlaceTypes(c.Context(), middleware.Locales(c), pt); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": pt})
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewDecisionRequest is the body for approve / reject of place workflow requests.
+type ReviewDecisionRequest struct {
+	Notes string `json:"notes"` // required for reject
+}
+
+// RequestPlaceVerification handles POST /api/places/:id/verification — submits evidence for review (201).
+func RequestPlaceVerification(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceVerificationService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.VerificationInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		vr, err := svc.Request(c.Context(), actorID, placeID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vr})
+	}
+}
+
+// ListPlaceVerifications returns GET /api/places/verifications?status=pending — the review queue.
+// Place names are localized (Accept-Language).
+func ListPlaceVerifications(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceVerificationService(db)
+	translations := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), c.Query("status"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		places := make([]*models.Place, 0, len(list))
+		for i := range list {
+			if list[i].Place != nil {
+				places = append(places, list[i].Place)
+			}
+		}
+		if err := translations.LocalizePlaces(c.Context(), middleware.Locales(c), places...); err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ApprovePlaceVerification handles POST /api/places/verifications/:id/approve.
+func ApprovePlaceVerification(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceVerificationService(db)
+	return func(c *fiber.Ctx) error {
+		id, reviewerID, req, ok := parseReviewDecision(c, "id")
+		if !ok {
+			return nil
+		}
+		vr, err := svc.Approve(c.Context(), reviewerID, id, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": vr})
+	}
+}
+
+// RejectPlaceVerification handles POST /api/places/verifications/:id/reject.
+func RejectPlaceVerification(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceVerificationService(db)
+	return func(c *fiber.Ctx) error {
+		id, reviewerID, req, ok := parseReviewDecision(c, "id")
+		if !ok {
+			return nil
+		}
+		vr, err := svc.Reject(c.Context(), reviewerID, id, req.Notes)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": vr})
+	}
+}
+
+// ListPlaceHistory returns GET /api/places/:id/history — paginated state changes, newest first.
+func ListPlaceHistory(db *gorm.DB) fiber.Handler {
+	svc := services.NewPlaceHistoryService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), placeID, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// parseReviewDecision reads the request id from the route param, the acting reviewer and the
+// optional body. On failure it writes the error response and returns ok=false.
+func parseReviewDecision(c *fiber.Ctx, param string) (uuid.UUID, uuid.UUID, ReviewDecisionRequest, bool) {
+	var req ReviewDecisionRequest
+	id, err := uuid.Parse(c.Params(param))
+	if err != nil {
+		_ = RespondError(c, rbacerrors.Invalid(param, rbacerrors.MsgInvalidID))
+		return uuid.Nil, uuid.Nil, req, false
+	}
+	actorID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		_ = RespondError(c, rbacerrors.ErrNotAuthenticated)
+		return uuid.Nil, uuid.Nil, req, false
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			_ = RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+			return uuid.Nil, uuid.Nil, req, false
+		}
+	}
+	return id, actorID, req, true
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RequestDataExport handles POST /api/me/export — queues a ZIP of the user's data (202).
+func RequestDataExport(db *gorm.DB) fiber.Handler {
+	svc := services.NewPrivacyService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		exp, err := svc.RequestExport(c.Context(), uid)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"data": exp})
+	}
+}
+
+// GetDataExport returns GET /api/me/export/:id — status and, when ready, a signed download_url.
+func GetDataExport(db *gorm.DB) fiber.Handler {
+	svc := services.NewPrivacyService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		exp, err := svc.GetExport(c.Context(), uid, id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": exp})
+	}
+}
+
+// DownloadDataExport serves GET /exports/:id/download?expires=&sig= (public; the signature is the credential).
+func DownloadDataExport(db *gorm.DB) fiber.Handler {
+	svc := services.NewPrivacyService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+		path, err := svc.ExportFile(c.Context(), id, expires, c.Query("sig"))
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Download(path, "ducksrow-export-"+id.String()+".zip")
+	}
+}
+
+// DeleteMe handles DELETE /api/me — schedules erasure of the account after the grace period (202).
+func DeleteMe(db *gorm.DB) fiber.Handler {
+	svc := services.NewPrivacyService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		due, err := svc.ScheduleErasure(c.Context(), uid)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"data": fiber.Map{"erasure_due_at": due.UTC().Format("2006-01-02T15:04:05.000Z")},
+		})
+	}
+}
+
+// CancelDeleteMe handles POST /api/me/erasure/cancel during the grace period.
+func CancelDeleteMe(db *gorm.DB) fiber.Handler {
+	svc := services.NewPrivacyService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		if err := svc.CancelErasure(c.Context(), uid); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
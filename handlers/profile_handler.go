@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerifyEmailRequest is the body for POST /api/me/email/verify.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// GetMe returns GET /api/me — the current user's profile, roles and pending email change.
+func GetMe(db *gorm.DB) fiber.Handler {
+	svc := services.NewProfileService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		p, err := svc.Get(c.Context(), uid)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": p})
+	}
+}
+
+// UpdateMe handles PATCH /api/me. Changing email starts a re-verification of the new address
+// and is refused under an impersonation token.
+func UpdateMe(db *gorm.DB) fiber.Handler {
+	svc := services.NewProfileService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.ProfileUpdate
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		if _, impersonating := c.Locals(models.ImpersonatorContextKey).(uuid.UUID); impersonating && req.Email != nil {
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrForbidden, rbacerrors.MsgImpersonating))
+		}
+		p, err := svc.Update(c.Context(), uid, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": p})
+	}
+}
+
+// VerifyEmail handles POST /api/me/email/verify — confirms a pending email change.
+func VerifyEmail(db *gorm.DB) fiber.Handler {
+	svc := services.NewProfileService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req VerifyEmailRequest
+		if err := c.BodyParser(&req); err != nil || req.Token == "" {
+			return RespondError(c, rbacerrors.Invalid("token", rbacerrors.MsgRequired))
+		}
+		p, err := svc.VerifyEmail(c.Context(), uid, req.Token)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": p})
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewReplyRequest is the body for PUT /api/places/:id/reviews/:rid/reply.
+type ReviewReplyRequest struct {
+	Text string `json:"text"`
+}
+
+// ListReviews returns GET /api/places/:id/reviews?sort=newest|oldest|highest|lowest.
+func ListReviews(db *gorm.DB) fiber.Handler {
+	svc := services.NewReviewService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), placeID, c.Query("sort"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// CreateReview handles POST /api/places/:id/reviews — the user's one review of the place (201).
+func CreateReview(db *gorm.DB) fiber.Handler {
+	svc := services.NewReviewService(db)
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		authorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		var req services.ReviewInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		review, err := svc.Create(c.Context(), authorID, placeID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": review})
+	}
+}
+
+// UpdateReview handles PATCH /api/places/:id/reviews/:rid — the author edits their review.
+func UpdateReview(db *gorm.DB) fiber.Handler {
+	svc := services.NewReviewService(db)
+	return reviewAction(func(c *fiber.Ctx, actorID, placeID, reviewID uuid.UUID) error {
+		var req services.ReviewInput
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		review, err := svc.Update(c.Context(), actorID, placeID, reviewID, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": review})
+	})
+}
+
+// DeleteReview handles DELETE /api/places/:id/reviews/:rid — by the author or with reviews:delete.
+func DeleteReview(db *gorm.DB) fiber.Handler {
+	svc := services.NewReviewService(db)
+	return reviewAction(func(c *fiber.Ctx, actorID, placeID, reviewID uuid.UUID) error {
+		if err := svc.Delete(c.Context(), actorID, placeID, reviewID); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
+
+// ReplyToReview handles PUT /api/places/:id/reviews/:rid/reply — the place owner's public reply
+// (an empty text removes it).
+func ReplyToReview(db *gorm.DB) fiber.Handler {
+	svc := services.NewReviewService(db)
+	return reviewAction(func(c *fiber.Ctx, actorID, placeID, reviewID uuid.UUID) error {
+		var req ReviewReplyRequest
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		review, err := svc.Reply(c.Context(), actorID, placeID, reviewID, req.Text)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": review})
+	})
+}
+
+// reviewAction parses :id, :rid and the acting user before running fn.
+func reviewAction(fn func(c *fiber.Ctx, actorID, placeID, reviewID uuid.UUID) error) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		placeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		reviewID, err := uuid.Parse(c.Params("rid"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("rid", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		return fn(c, actorID, placeID, reviewID)
+	}
+}
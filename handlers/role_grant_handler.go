@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DecideGrantRequest is the body for POST /api/roles/grants/:id/approve and /reject.
+type DecideGrantRequest struct {
+	Reason string `json:"reason"` // required for reject
+}
+
+// ListRoleGrants returns GET /api/roles/grants — paginated grant requests, filter with ?status=pending.
+func ListRoleGrants(db *gorm.DB) fiber.Handler {
+	svc := services.NewRoleGrantService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), c.Query("status"), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// ApproveRoleGrant handles POST /api/roles/grants/:id/approve.
+func ApproveRoleGrant(db *gorm.DB) fiber.Handler {
+	svc := services.NewRoleGrantService(db)
+	return func(c *fiber.Ctx) error {
+		id, approverID, req, ok := parseGrantDecision(c)
+		if !ok {
+			return nil
+		}
+		grant, err := svc.Approve(c.Context(), approverID, id, req.Reason)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": grant})
+	}
+}
+
+// RejectRoleGrant handles POST /api/roles/grants/:id/reject.
+func RejectRoleGrant(db *gorm.DB) fiber.Handler {
+	svc := services.NewRoleGrantService(db)
+	return func(c *fiber.Ctx) error {
+		id, approverID, req, ok := parseGrantDecision(c)
+		if !ok {
+			return nil
+		}
+		grant, err := svc.Reject(c.Context(), approverID, id, req.Reason)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": grant})
+	}
+}
+
+// parseGrantDecision reads the grant id, the acting admin and the optional body.
+// On failure it writes the error response and returns ok=false.
+func parseGrantDecision(c *fiber.Ctx) (uuid.UUID, uuid.UUID, DecideGrantRequest, bool) {
+	var req DecideGrantRequest
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		_ = RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		return uuid.Nil, uuid.Nil, req, false
+	}
+	actorID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		_ = RespondError(c, rbacerrors.ErrNotAuthenticated)
+		return uuid.Nil, uuid.Nil, req, false
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			_ = RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+			return uuid.Nil, uuid.Nil, req, false
+		}
+	}
+	return id, actorID, req, true
+}
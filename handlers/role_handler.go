@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"errors"
 	"strconv"
 
 	rbacerrors "ducksrow/backend/errors"
@@ -33,10 +32,7 @@ func ListRoles(db *gorm.DB) fiber.Handler {
 		limit, _ := strconv.Atoi(c.Query("limit", "20"))
 		list, total, err := svc.List(c.Context(), page, limit)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to list roles",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		return c.JSON(fiber.Map{
 			"data": list,
@@ -51,15 +47,11 @@ func CreateRole(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var req CreateRoleRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid body",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
 		}
 		role, err := svc.Create(c.Context(), req.Slug, req.Name, req.Permissions)
 		if err != nil {
-			status, code := rbacerrors.HTTPStatusAndCode(err)
-			return c.Status(status).JSON(fiber.Map{"error": err.Error(), "code": code})
+			return RespondError(c, err)
 		}
 		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": role})
 	}
@@ -71,23 +63,11 @@ func GetRole(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid role id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
 		role, err := svc.GetByID(c.Context(), id)
 		if err != nil {
-			if errors.Is(err, rbacerrors.ErrRoleNotFound) {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "role not found",
-					"code":  "NOT_FOUND",
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to get role",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		return c.JSON(fiber.Map{"data": role})
 	}
@@ -99,22 +79,15 @@ func UpdateRole(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid role id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
 		var req UpdateRoleRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid body",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
 		}
 		role, err := svc.Update(c.Context(), id, req.Name, req.Permissions)
 		if err != nil {
-			status, code := rbacerrors.HTTPStatusAndCode(err)
-			return c.Status(status).JSON(fiber.Map{"error": err.Error(), "code": code})
+			return RespondError(c, err)
 		}
 		return c.JSON(fiber.Map{"data": role})
 	}
@@ -126,28 +99,10 @@ func DeleteRole(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid role id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
 		if err := svc.Delete(c.Context(), id); err != nil {
-			if errors.Is(err, rbacerrors.ErrRoleNotFound) {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "role not found",
-					"code":  "NOT_FOUND",
-				})
-			}
-			if errors.Is(err, rbacerrors.ErrSystemRoleProtected) {
-				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-					"error": err.Error(),
-					"code":  "FORBIDDEN",
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to delete role",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		return c.SendStatus(fiber.StatusNoContent)
 	}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/middleware"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BatchMembersRequest is the body for POST /api/roles/:id/members:batch.
+// Each entry in add / remove is a user UUID or an email address.
+type BatchMembersRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+	Mode   string   `json:"mode"` // atomic (default) | partial
+}
+
+// ListRoleMembers returns GET /api/roles/:id/members — paginated users holding the role.
+func ListRoleMembers(db *gorm.DB) fiber.Handler {
+	svc := services.NewRoleMemberService(db)
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.ListMembers(c.Context(), roleID, page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// BatchRoleMembers handles POST /api/roles/:id/members:batch — bulk add / remove.
+// Atomic mode rolls everything back on the first failing item; partial mode reports per-item results.
+func BatchRoleMembers(db *gorm.DB) fiber.Handler {
+	svc := services.NewRoleMemberService(db)
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		var req BatchMembersRequest
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		if req.Mode != "" && req.Mode != "atomic" && req.Mode != "partial" {
+			return RespondError(c, rbacerrors.Invalid("mode", rbacerrors.MsgOneOf, "values", "atomic, partial"))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		results, err := svc.Batch(c.Context(), actorID, roleID, req.Add, req.Remove, req.Mode == "partial")
+		if err != nil {
+			var batchErr *services.BatchError
+			if errors.As(err, &batchErr) {
+				return middleware.RespondErrorWith(c, batchErr.Err, 0, fiber.Map{"item": batchErr.Item})
+			}
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": results})
+	}
+}
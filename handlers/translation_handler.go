@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"strconv"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetTranslations returns GET /api/places/:id/translations (or /api/place-types/:id/translations):
+// {"data": {"<locale>": {"<field>": "..."}}}.
+func GetTranslations(db *gorm.DB, entityType string) fiber.Handler {
+	svc := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		set, err := svc.Get(c.Context(), entityType, id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": set})
+	}
+}
+
+// SetTranslations handles PUT /api/places/:id/translations (or /api/place-types/:id/translations).
+// Body: {"fr": {"name": "...", "description": "..."}, "ar": {...}}; place types translate "name"
+// and "form.<key>" (form field labels). An empty value removes the translation; locales and
+// fields not in the body are left unchanged.
+func SetTranslations(db *gorm.DB, entityType string) fiber.Handler {
+	svc := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		var req services.TranslationSet
+		if err := c.BodyParser(&req); err != nil {
+			return RespondError(c, rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		set, err := svc.Set(c.Context(), &actorID, entityType, id, req)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": set})
+	}
+}
+
+// ListMissingTranslations returns GET /api/translations/missing?locale=fr&entity_type=place|place_type
+// — paginated fields that have a source value but no translation in the locale.
+func ListMissingTranslations(db *gorm.DB) fiber.Handler {
+	svc := services.NewTranslationService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.Missing(c.Context(), c.Query("locale"), c.Query("entity_type", models.TranslationPlace), page, limit)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
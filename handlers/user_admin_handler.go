@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SuspendUserRequest is the body for POST /api/users/:id/suspend.
+type SuspendUserRequest struct {
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until"` // optional RFC 3339; omit to suspend indefinitely
+}
+
+// ListUsers returns GET /api/users — search by ?q= (email, username, name, name_local), ?role=, ?status=.
+func ListUsers(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return func(c *fiber.Ctx) error {
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		list, total, err := svc.List(c.Context(), services.UserListFilter{
+			Query:  c.Query("q"),
+			Role:   c.Query("role"),
+			Status: c.Query("status"),
+			Page:   page,
+			Limit:  limit,
+		})
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"data": list,
+			"meta": fiber.Map{"page": page, "limit": limit, "total": total},
+		})
+	}
+}
+
+// GetUser returns GET /api/users/:id — user with roles and counts of places and plans.
+func GetUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		user, err := svc.Get(c.Context(), id)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		return c.JSON(fiber.Map{"data": user})
+	}
+}
+
+// SuspendUser handles POST /api/users/:id/suspend.
+func SuspendUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return userAction(func(c *fiber.Ctx, actorID, userID uuid.UUID) error {
+		var req SuspendUserRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody)
+			}
+		}
+		return svc.Suspend(c.Context(), actorID, userID, req.Reason, req.Until)
+	})
+}
+
+// UnsuspendUser handles POST /api/users/:id/unsuspend.
+func UnsuspendUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return userAction(func(c *fiber.Ctx, actorID, userID uuid.UUID) error {
+		return svc.Unsuspend(c.Context(), actorID, userID)
+	})
+}
+
+// UpdateUser handles PATCH /api/users/:id — admin-managed attributes such as city.
+func UpdateUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return userAction(func(c *fiber.Ctx, actorID, userID uuid.UUID) error {
+		var req services.UserUpdateInput
+		if err := c.BodyParser(&req); err != nil {
+			return rbacerrors.Invalid("body", rbacerrors.MsgInvalidBody)
+		}
+		return svc.Update(c.Context(), actorID, userID, req)
+	})
+}
+
+// DeleteUser handles DELETE /api/users/:id (soft delete).
+func DeleteUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return userAction(func(c *fiber.Ctx, actorID, userID uuid.UUID) error {
+		return svc.Delete(c.Context(), actorID, userID)
+	})
+}
+
+// RestoreUser handles POST /api/users/:id/restore.
+func RestoreUser(db *gorm.DB) fiber.Handler {
+	svc := services.NewUserAdminService(db)
+	return userAction(func(c *fiber.Ctx, actorID, userID uuid.UUID) error {
+		return svc.Restore(c.Context(), actorID, userID)
+	})
+}
+
+// userAction parses :id and the acting user, runs fn and replies 204 or the mapped error.
+func userAction(fn func(c *fiber.Ctx, actorID, userID uuid.UUID) error) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
+		}
+		actorID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		if err := fn(c, actorID, userID); err != nil {
+			return RespondError(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
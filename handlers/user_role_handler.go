@@ -1,9 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"errors"
-
 	rbacerrors "ducksrow/backend/errors"
 	"ducksrow/backend/services"
 
@@ -15,6 +12,7 @@ import (
 // AssignRoleRequest is the body for POST /api/users/:id/roles.
 type AssignRoleRequest struct {
 	RoleID string `json:"role_id"` // UUID, required
+	Reason string `json:"reason"`  // optional; recorded on pending grants of sensitive roles
 }
 
 // ListUserRoles returns GET /api/users/:id/roles — list roles for a user.
@@ -23,73 +21,45 @@ func ListUserRoles(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid user id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
-		list, err := svc.ListForUser(context.Background(), userID)
+		list, err := svc.ListForUser(c.Context(), userID)
 		if err != nil {
-			if errors.Is(err, rbacerrors.ErrUserNotFound) {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "user not found",
-					"code":  "NOT_FOUND",
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to list roles",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		return c.JSON(fiber.Map{"data": list})
 	}
 }
 
 // AssignRole handles POST /api/users/:id/roles — assign role to user.
+// Sensitive roles are not assigned immediately: a pending grant is created (202) for a second admin to approve.
 func AssignRole(db *gorm.DB) fiber.Handler {
-	svc := services.NewUserRoleService(db)
+	svc := services.NewRoleGrantService(db)
 	return func(c *fiber.Ctx) error {
 		userID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid user id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
 		var req AssignRoleRequest
 		if err := c.BodyParser(&req); err != nil || req.RoleID == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "role_id is required",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("role_id", rbacerrors.MsgRequired))
 		}
 		roleID, err := uuid.Parse(req.RoleID)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid role_id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("role_id", rbacerrors.MsgInvalidID))
 		}
 		actorID, ok := c.Locals("userID").(uuid.UUID)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "user not authenticated",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
 		}
-		result, err := svc.Assign(context.Background(), actorID, userID, roleID)
+		grant, err := svc.Grant(c.Context(), actorID, userID, roleID, req.Reason)
 		if err != nil {
-			if errors.Is(err, rbacerrors.ErrUserNotFound) || errors.Is(err, rbacerrors.ErrRoleNotFound) {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": err.Error(),
-					"code":  "NOT_FOUND",
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to assign role",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
+		if grant.Pending != nil {
+			return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"data": grant.Pending})
+		}
+		result := grant.Assignment
 		if result.Created {
 			return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 				"data": fiber.Map{
@@ -119,36 +89,18 @@ func UnassignRole(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid user id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("id", rbacerrors.MsgInvalidID))
 		}
 		roleID, err := uuid.Parse(c.Params("roleId"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid role id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid("roleId", rbacerrors.MsgInvalidID))
 		}
 		actorID, ok := c.Locals("userID").(uuid.UUID)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "user not authenticated",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
 		}
-		if err := svc.Unassign(context.Background(), actorID, userID, roleID); err != nil {
-			if errors.Is(err, rbacerrors.ErrAssignmentNotFound) || errors.Is(err, rbacerrors.ErrUserNotFound) || errors.Is(err, rbacerrors.ErrRoleNotFound) {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": err.Error(),
-					"code":  "NOT_FOUND",
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "failed to remove role",
-				"code":  "INTERNAL_ERROR",
-			})
+		if err := svc.Unassign(c.Context(), actorID, userID, roleID); err != nil {
+			return RespondError(c, err)
 		}
 		return c.SendStatus(fiber.StatusNoContent)
 	}
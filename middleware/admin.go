@@ -3,6 +3,11 @@ package middleware
 import (
 	"os"
 	"strings"
+	"time"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/models"
+	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -10,9 +15,12 @@ import (
 	"gorm.io/gorm"
 )
 
-// AdminOnly parses the JWT and returns 403 if the user does not have the admin role (via user_roles).
-// Expects "Authorization: Bearer <token>". Use for admin-only routes. Pass db to query user_roles.
+// AdminOnly parses the JWT and returns 403 if the user does not have the admin role (via user_roles),
+// or if the token is an impersonation token (admin actions are never allowed while impersonating).
+// Expects "Authorization: Bearer <token>". Use for admin-only routes. Pass db to query user_roles
+// (through the cached PermissionService).
 func AdminOnly(db *gorm.DB) fiber.Handler {
+	permSvc := services.NewPermissionService(db)
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
 		secret = "change-me-in-production"
@@ -20,51 +28,36 @@ func AdminOnly(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		auth := c.Get("Authorization")
 		if auth == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "missing authorization header",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgMissingToken))
 		}
 		parts := strings.SplitN(auth, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid authorization format",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidAuthFormat))
 		}
 		token, err := jwt.ParseWithClaims(parts[1], &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 			return []byte(secret), nil
 		})
 		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid or expired token",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
 		}
 		claims, ok := token.Claims.(*JWTClaims)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid token claims",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
+		}
+		if claims.ImpersonatorID != "" {
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrForbidden, rbacerrors.MsgImpersonating))
 		}
 		userID, err := uuid.Parse(claims.UserID)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid user id in token",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
+		}
+		var user models.User
+		if err := db.First(&user, "id = ?", userID).Error; err != nil || user.IsSuspended(time.Now()) {
+			return RespondError(c, rbacerrors.ErrForbidden)
 		}
-		var n int
-		err = db.Raw(
-			"SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL WHERE ur.user_id = ? AND r.slug = 'admin' LIMIT 1",
-			userID,
-		).Scan(&n).Error
-		if err != nil || n != 1 {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Insufficient permissions",
-				"code":  "FORBIDDEN",
-			})
+		isAdmin, err := permSvc.IsAdmin(c.Context(), userID)
+		if err != nil || !isAdmin {
+			return RespondError(c, rbacerrors.ErrForbidden)
 		}
 		c.Locals("userID", userID)
 		return c.Next()
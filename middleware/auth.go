@@ -3,8 +3,11 @@ package middleware
 import (
 	"os"
 	"strings"
+	"time"
 
+	rbacerrors "ducksrow/backend/errors"
 	"ducksrow/backend/models"
+	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -16,10 +19,13 @@ import (
 type JWTClaims struct {
 	UserID string `json:"user_id"` // UUID string
 	Email  string `json:"email"`
+	// ImpersonatorID is set on impersonation tokens: UserID is the target, this is the admin.
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Protected validates the JWT and sets the user in the context.
+// Protected validates the JWT, rejects deleted and suspended users, and sets the user in the context.
+// Impersonation tokens are rejected once the impersonating admin is deleted, suspended or demoted.
 // Expects "Authorization: Bearer <token>".
 func Protected(db *gorm.DB) fiber.Handler {
 	secret := os.Getenv("JWT_SECRET")
@@ -29,45 +35,59 @@ func Protected(db *gorm.DB) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		auth := c.Get("Authorization")
 		if auth == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "missing authorization header",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgMissingToken))
 		}
 		parts := strings.SplitN(auth, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid authorization format",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidAuthFormat))
 		}
 		tokenString := parts[1]
 		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 			return []byte(secret), nil
 		})
 		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid or expired token",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
 		}
 		claims, ok := token.Claims.(*JWTClaims)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid token claims",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
 		}
 		userID, err := uuid.Parse(claims.UserID)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid user id in token",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
 		}
 		var user models.User
 		if err := db.First(&user, "id = ?", userID).Error; err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "user not found",
-			})
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
+		}
+		if user.IsSuspended(time.Now()) {
+			return RespondError(c, rbacerrors.ErrAccountSuspended)
+		}
+		if claims.ImpersonatorID != "" {
+			impID, err := uuid.Parse(claims.ImpersonatorID)
+			if err != nil {
+				return RespondError(c, rbacerrors.Msg(rbacerrors.ErrNotAuthenticated, rbacerrors.MsgInvalidToken))
+			}
+			if err := services.NewImpersonationService(db).CheckImpersonator(c.Context(), impID); err != nil {
+				return RespondError(c, err)
+			}
+			c.Locals(models.ImpersonatorContextKey, impID)
+		} else {
+			services.RecordActivity(db, userID)
 		}
 		c.Locals("user", &user)
 		c.Locals("userID", userID)
 		return c.Next()
 	}
 }
+
+// NoImpersonation rejects requests made with an impersonation token. Use on sensitive routes
+// (password / email change, role management). Expects Protected(db) to have run first.
+func NoImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals(models.ImpersonatorContextKey).(uuid.UUID); ok {
+			return RespondError(c, rbacerrors.Msg(rbacerrors.ErrForbidden, rbacerrors.MsgImpersonating))
+		}
+		return c.Next()
+	}
+}
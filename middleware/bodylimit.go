@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// RouteBodyLimits lets the given routes ("POST /api/places/imports") accept bodies up to their own
+// limit instead of the app's BodyLimit, which keeps applying to every other route. fasthttp picks
+// the limit once the request headers are read, before the body is.
+func RouteBodyLimits(app *fiber.App, limits map[string]int) {
+	app.Server().HeaderReceived = func(h *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		path, _, _ := strings.Cut(string(h.RequestURI()), "?")
+		if limit, ok := limits[string(h.Method())+" "+strings.TrimSuffix(path, "/")]; ok {
+			return fasthttp.RequestConfig{MaxRequestBodySize: limit}
+		}
+		return fasthttp.RequestConfig{}
+	}
+}
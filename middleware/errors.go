@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strings"
+
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEProblemJSON is the RFC 7807 problem document media type.
+const MIMEProblemJSON = "application/problem+json"
+
+// problemMode is ERROR_FORMAT=problem: every error is a problem document, whatever the client accepts.
+// problemTypeBase (PROBLEM_TYPE_BASE, default "/problems/") prefixes the problem type URI.
+var problemMode, problemTypeBase = problemConfigFromEnv()
+
+func problemConfigFromEnv() (bool, string) {
+	base := os.Getenv("PROBLEM_TYPE_BASE")
+	if base == "" {
+		base = "/problems/"
+	}
+	return strings.EqualFold(os.Getenv("ERROR_FORMAT"), "problem"), base
+}
+
+// fiberErrorCodes maps the statuses of Fiber's own errors (unknown route, body too large, ...)
+// to machine codes.
+var fiberErrorCodes = map[int]string{
+	fiber.StatusBadRequest:            "VALIDATION_ERROR",
+	fiber.StatusUnauthorized:          "UNAUTHORIZED",
+	fiber.StatusForbidden:             "FORBIDDEN",
+	fiber.StatusNotFound:              "NOT_FOUND",
+	fiber.StatusMethodNotAllowed:      "METHOD_NOT_ALLOWED",
+	fiber.StatusConflict:              "CONFLICT",
+	fiber.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+	fiber.StatusUnprocessableEntity:   "UNPROCESSABLE",
+	fiber.StatusTooManyRequests:       "TOO_MANY_REQUESTS",
+}
+
+// Locales returns the locale fallback chain negotiated by Locale.
+func Locales(c *fiber.Ctx) []string {
+	if locales, ok := c.Locals("locales").([]string); ok {
+		return locales
+	}
+	return services.NegotiateLocales(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// RespondError maps a domain error to an HTTP response. The standard envelope is
+// {"error": "<message in the request's language>", "code": "<machine code>"}, plus "fields" for
+// field-level validation failures and "detail" when a wrapped error's English detail could not be
+// translated. With ERROR_FORMAT=problem, or when the client accepts application/problem+json, the
+// error is an RFC 7807 problem document instead (see problemDocument). Unknown errors return
+// 500 INTERNAL_ERROR and are logged.
+func RespondError(c *fiber.Ctx, err error) error {
+	return RespondErrorWith(c, err, 0, nil)
+}
+
+// RespondErrorWith is RespondError with a status override (0 keeps the mapped one) and extra
+// members added to the body, e.g. the candidates of a duplicate place. A "code" extra replaces
+// the mapped code.
+func RespondErrorWith(c *fiber.Ctx, err error, status int, extra fiber.Map) error {
+	mapped, code := rbacerrors.HTTPStatusAndCode(err)
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		mapped, code = fe.Code, fiberErrorCode(fe.Code)
+	}
+	if status == 0 {
+		status = mapped
+	}
+	locales := Locales(c)
+	// The title stays the generic message of the mapped code when an extra overrides the code.
+	title := rbacerrors.Translate(locales, code, nil)
+	if override, ok := extra["code"].(string); ok {
+		code = override
+	}
+	if status >= fiber.StatusInternalServerError && err != nil {
+		log.Printf("[%s] %s %s: %v", requestID(c), c.Method(), c.Path(), err)
+	}
+
+	var message, detail string
+	var fields []rbacerrors.FieldError
+	if fe != nil {
+		// Fiber's messages ("Cannot GET /x") are English only.
+		message = title
+		if status < fiber.StatusInternalServerError {
+			detail = fe.Message
+		}
+	} else {
+		message, detail, fields = rbacerrors.Localize(err, locales)
+	}
+
+	if wantsProblem(c) {
+		body := problemDocument(c, status, code, title, message)
+		if detail != "" {
+			body["detail_en"] = detail
+		}
+		if len(fields) > 0 {
+			body["errors"] = fields
+		}
+		for k, v := range extra {
+			body[k] = v
+		}
+		return c.Status(status).JSON(body, MIMEProblemJSON)
+	}
+	body := fiber.Map{"error": message, "code": code}
+	if detail != "" {
+		body["detail"] = detail
+	}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+	return c.Status(status).JSON(body)
+}
+
+// ErrorHandler is the Fiber ErrorHandler: errors returned by handlers and middleware (including
+// panics turned into errors by recover) are answered like RespondError.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return RespondError(c, err)
+}
+
+// problemDocument returns the RFC 7807 members: type (PROBLEM_TYPE_BASE + the kebab-case code),
+// title (the generic message for the code), status, detail (the specific message), instance (the
+// request URI), plus the extensions code and request_id.
+func problemDocument(c *fiber.Ctx, status int, code, title, detail string) fiber.Map {
+	return fiber.Map{
+		"type":       problemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		"title":      title,
+		"status":     status,
+		"detail":     detail,
+		"instance":   c.OriginalURL(),
+		"code":       code,
+		"request_id": requestID(c),
+	}
+}
+
+// wantsProblem reports whether the error should be a problem document.
+func wantsProblem(c *fiber.Ctx) bool {
+	return problemMode || strings.Contains(c.Get(fiber.HeaderAccept), MIMEProblemJSON)
+}
+
+func fiberErrorCode(status int) string {
+	if code, ok := fiberErrorCodes[status]; ok {
+		return code
+	}
+	if status >= fiber.StatusInternalServerError {
+		return "INTERNAL_ERROR"
+	}
+	return "VALIDATION_ERROR"
+}
+
+// requestID returns the ID set by the requestid middleware ("" when it is not installed).
+func requestID(c *fiber.Ctx) string {
+	id, _ := c.Locals("requestid").(string)
+	return id
+}
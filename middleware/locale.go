@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale negotiates the response language from Accept-Language and stores the locale fallback
+// chain in c.Locals("locales") ([]string, see services.NegotiateLocales). Sets Content-Language
+// to the preferred locale and varies responses on Accept-Language.
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		locales := services.NegotiateLocales(c.Get(fiber.HeaderAcceptLanguage))
+		c.Locals("locales", locales)
+		c.Set(fiber.HeaderContentLanguage, locales[0])
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ducksrow/backend/abac"
+	rbacerrors "ducksrow/backend/errors"
+	"ducksrow/backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RequirePolicy evaluates the stored access policies for action after RBAC has allowed the request.
+// Expects Protected(db) and RequirePermission to have run first. resourceType ("place", "plan") and
+// idParam (route param holding the resource ID, "" for create routes) select the resource attributes.
+// The request context exposes method, path, ip, time (RFC 3339), hour / weekday (UTC), the JSON body
+// as context.body and its top-level keys as context.fields.
+func RequirePolicy(db *gorm.DB, action, resourceType, idParam string) fiber.Handler {
+	svc := services.NewAccessPolicyService(db)
+	return func(c *fiber.Ctx) error {
+		uid, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
+		}
+		rules, err := svc.RulesFor(c.Context(), action)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		if len(rules) == 0 {
+			return c.Next()
+		}
+		subject, err := svc.SubjectAttributes(c.Context(), uid)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		resID := uuid.Nil
+		if idParam != "" {
+			resID, _ = uuid.Parse(c.Params(idParam))
+		}
+		resource, err := svc.ResourceAttributes(c.Context(), resourceType, resID)
+		if err != nil {
+			return RespondError(c, err)
+		}
+		d := abac.Evaluate(rules, abac.Request{
+			Action:   action,
+			Subject:  subject,
+			Resource: resource,
+			Context:  requestContext(c),
+		})
+		if !d.Allowed {
+			return RespondError(c, fmt.Errorf("%w: %s", rbacerrors.ErrPolicyDenied, d.RuleName))
+		}
+		return c.Next()
+	}
+}
+
+func requestContext(c *fiber.Ctx) map[string]interface{} {
+	now := time.Now().UTC()
+	ctx := map[string]interface{}{
+		"method":  c.Method(),
+		"path":    c.Path(),
+		"ip":      c.IP(),
+		"time":    now.Format(time.RFC3339),
+		"hour":    now.Hour(),
+		"weekday": now.Weekday().String(),
+	}
+	var body map[string]interface{}
+	if len(c.Body()) > 0 && json.Unmarshal(c.Body(), &body) == nil {
+		fields := make([]interface{}, 0, len(body))
+		for k := range body {
+			fields = append(fields, k)
+		}
+		ctx["body"] = body
+		ctx["fields"] = fields
+	}
+	return ctx
+}
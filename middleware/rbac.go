@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	rbacerrors "ducksrow/backend/errors"
 	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -31,23 +32,14 @@ func requirePermissionSvc(svc permissionChecker, permission string) fiber.Handle
 	return func(c *fiber.Ctx) error {
 		uid, ok := c.Locals("userID").(uuid.UUID)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "user not authenticated",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
 		}
 		hasPerm, err := svc.HasPermission(c.Context(), uid, permission)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "permission check failed",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		if !hasPerm {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Insufficient permissions",
-				"code":  "FORBIDDEN",
-			})
+			return RespondError(c, rbacerrors.ErrForbidden)
 		}
 		return c.Next()
 	}
@@ -59,17 +51,11 @@ func RequireOwnershipOrPermission(permSvc permissionChecker, ownerSvc ownershipC
 	return func(c *fiber.Ctx) error {
 		uid, ok := c.Locals("userID").(uuid.UUID)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "user not authenticated",
-				"code":  "UNAUTHORIZED",
-			})
+			return RespondError(c, rbacerrors.ErrNotAuthenticated)
 		}
 		hasFull, err := permSvc.HasPermission(c.Context(), uid, fullPerm)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "permission check failed",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		if hasFull {
 			return c.Next()
@@ -77,36 +63,21 @@ func RequireOwnershipOrPermission(permSvc permissionChecker, ownerSvc ownershipC
 		hasOwn, err := permSvc.HasPermission(c.Context(), uid, ownPerm)
 		if err != nil || !hasOwn {
 			if !hasOwn {
-				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-					"error": "Insufficient permissions",
-					"code":  "FORBIDDEN",
-				})
+				return RespondError(c, rbacerrors.ErrForbidden)
 			}
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "permission check failed",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		placeIDStr := c.Params(paramName)
 		placeID, err := uuid.Parse(placeIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid place id",
-				"code":  "VALIDATION_ERROR",
-			})
+			return RespondError(c, rbacerrors.Invalid(paramName, rbacerrors.MsgInvalidID))
 		}
 		owned, err := ownerSvc.IsOwner(c.Context(), placeID, uid)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "ownership check failed",
-				"code":  "INTERNAL_ERROR",
-			})
+			return RespondError(c, err)
 		}
 		if !owned {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Insufficient permissions",
-				"code":  "FORBIDDEN",
-			})
+			return RespondError(c, rbacerrors.ErrForbidden)
 		}
 		return c.Next()
 	}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyCondition is one attribute comparison of an access policy (see package abac).
+type PolicyCondition struct {
+	Attr      string      `json:"attr"`
+	Op        string      `json:"op"`
+	Value     interface{} `json:"value,omitempty"`
+	ValueAttr string      `json:"value_attr,omitempty"`
+}
+
+// PolicyConditionsJSON holds the all / any condition lists of an access policy (JSONB).
+type PolicyConditionsJSON struct {
+	All []PolicyCondition `json:"all,omitempty"`
+	Any []PolicyCondition `json:"any,omitempty"`
+}
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j PolicyConditionsJSON) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *PolicyConditionsJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = PolicyConditionsJSON{}
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("conditions: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// AccessPolicy is an attribute-based rule evaluated after the RBAC permission check.
+type AccessPolicy struct {
+	ID          uuid.UUID            `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string               `gorm:"size:255;not null;uniqueIndex:idx_access_policies_name,where:deleted_at IS NULL" json:"name"`
+	Description string               `gorm:"type:text" json:"description"`
+	Action      string               `gorm:"size:100;not null;index" json:"action"` // permission key, "resource:*" or "*"
+	Effect      string               `gorm:"size:10;not null" json:"effect"`        // allow | deny
+	Priority    int                  `gorm:"not null;default:0" json:"priority"`
+	Conditions  PolicyConditionsJSON `gorm:"type:jsonb;not null" json:"conditions"`
+	Enabled     bool                 `gorm:"not null" json:"enabled"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// TableName overrides the table name.
+func (AccessPolicy) TableName() string {
+	return "access_policies"
+}
+
+// BeforeCreate sets ID if not set.
+func (p *AccessPolicy) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Data export statuses.
+const (
+	ExportStatusPending  = "pending"
+	ExportStatusBuilding = "building"
+	ExportStatusReady    = "ready"
+	ExportStatusFailed   = "failed"
+)
+
+// DataExport is a user's request for a ZIP of their personal data (GDPR access / portability).
+// FilePath is only set once the archive is ready; the file is removed when ExpiresAt passes.
+type DataExport struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status      string     `gorm:"size:20;not null;index" json:"status"` // pending | building | ready | failed
+	FilePath    string     `gorm:"size:512" json:"-"`
+	SizeBytes   int64      `json:"size_bytes,omitempty"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// BeforeCreate sets ID if not set.
+func (e *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
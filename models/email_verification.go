@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailVerification is a pending change of a user's email address. The new address is
+// applied only once the token sent to it is confirmed. Only the SHA-256 of the token is stored.
+type EmailVerification struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Email      string     `gorm:"size:255;not null" json:"email"`
+	TokenHash  string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName overrides the table name.
+func (EmailVerification) TableName() string {
+	return "email_verifications"
+}
+
+// BeforeCreate sets ID if not set.
+func (e *EmailVerification) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
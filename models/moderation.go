@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Content that can be reported and moderated.
+const (
+	ModerationTargetReview = "review"
+	ModerationTargetPlace  = "place"
+	ModerationTargetPlan   = "plan" // public plans only
+)
+
+// Content report statuses. A report stays open until a moderator acts on its target.
+const (
+	ReportOpen      = "open"
+	ReportActioned  = "actioned"
+	ReportDismissed = "dismissed"
+)
+
+// Moderation actions. hide / unhide toggle HiddenAt on the target, delete removes it, warn and
+// suspend apply to its author, dismiss closes the reports without changing anything.
+const (
+	ModerationHide    = "hide"
+	ModerationUnhide  = "unhide"
+	ModerationDelete  = "delete"
+	ModerationWarn    = "warn"
+	ModerationSuspend = "suspend"
+	ModerationDismiss = "dismiss"
+)
+
+// ContentReport is a user's report of a review, place or public plan (one open report per user and
+// target; a resolved report does not stop the user reporting the target again).
+type ContentReport struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TargetType string     `gorm:"size:20;not null;uniqueIndex:idx_content_report_reporter,where:status = 'open';index:idx_content_report_target" json:"target_type"`
+	TargetID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_content_report_reporter,where:status = 'open';index:idx_content_report_target" json:"target_id"`
+	ReporterID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_content_report_reporter,where:status = 'open'" json:"reporter_id"`
+	Reason     string     `gorm:"type:text;not null" json:"reason"`
+	Status     string     `gorm:"size:20;not null;index" json:"status"` // open | actioned | dismissed
+	ResolvedBy *uuid.UUID `gorm:"type:uuid" json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (ContentReport) TableName() string {
+	return "content_reports"
+}
+
+// BeforeCreate sets ID if not set.
+func (r *ContentReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// ModerationAction records each moderation action on reported content (append-only).
+// ActorID is nil when the target was hidden automatically after enough reports.
+type ModerationAction struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TargetType   string     `gorm:"size:20;not null;index:idx_moderation_action_target" json:"target_type"`
+	TargetID     uuid.UUID  `gorm:"type:uuid;not null;index:idx_moderation_action_target" json:"target_id"`
+	Action       string     `gorm:"size:20;not null" json:"action"` // see Moderation actions
+	ActorID      *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	TargetUserID *uuid.UUID `gorm:"type:uuid;index" json:"target_user_id,omitempty"` // author of the content
+	Reason       string     `gorm:"type:text" json:"reason,omitempty"`
+	// ImpersonatorID is the real actor when ActorID was acting under an impersonation token.
+	ImpersonatorID *uuid.UUID `gorm:"type:uuid" json:"impersonator_id,omitempty"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+// TableName overrides the table name.
+func (ModerationAction) TableName() string {
+	return "moderation_actions"
+}
+
+// BeforeCreate sets ID if not set and ImpersonatorID from the statement context (see ImpersonatorFrom).
+func (a *ModerationAction) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	if a.ImpersonatorID == nil {
+		a.ImpersonatorID = ImpersonatorFrom(tx)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Weekday keys of OpeningWeekJSON, Monday first (index = ISO day of week - 1).
+var OpeningWeekdays = []string{"mo", "tu", "we", "th", "fr", "sa", "su"}
+
+// OpeningInterval is one opening span in local time ("HH:MM"). Closes at or before Opens means
+// the span runs past midnight; "24:00" closes at the end of the day.
+type OpeningInterval struct {
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// OpeningException replaces the weekly schedule on one local date (YYYY-MM-DD); no intervals
+// means closed all day.
+type OpeningException struct {
+	Date      string            `json:"date"`
+	Intervals []OpeningInterval `json:"intervals"`
+}
+
+// OpeningWeekJSON maps weekday keys (mo … su) to that day's intervals (JSONB). Missing days are closed.
+type OpeningWeekJSON map[string][]OpeningInterval
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j OpeningWeekJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return "{}", nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *OpeningWeekJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("opening week: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// OpeningExceptionsJSON is the list of date exceptions (JSONB).
+type OpeningExceptionsJSON []OpeningException
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j OpeningExceptionsJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return "[]", nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *OpeningExceptionsJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("opening exceptions: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// PlaceOpeningHours is the opening-hours schedule of a place, in the place's timezone.
+// OSM is the same schedule in OpenStreetMap opening_hours syntax.
+type PlaceOpeningHours struct {
+	PlaceID    uuid.UUID             `gorm:"type:uuid;primaryKey" json:"place_id"`
+	Timezone   string                `gorm:"size:64;not null" json:"timezone"` // IANA name, e.g. Europe/Paris
+	Weekly     OpeningWeekJSON       `gorm:"type:jsonb;not null" json:"weekly"`
+	Exceptions OpeningExceptionsJSON `gorm:"type:jsonb;not null" json:"exceptions"`
+	OSM        string                `gorm:"type:text;not null;default:''" json:"osm"`
+	UpdatedBy  *uuid.UUID            `gorm:"type:uuid" json:"updated_by,omitempty"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceOpeningHours) TableName() string {
+	return "place_opening_hours"
+}
+
+// PlaceOpeningInterval is the schedule flattened for "open at" queries (derived from
+// PlaceOpeningHours, rewritten on every change). Weekly rows have Weekday (0 = Monday) and no
+// Date; exception rows have Date. Opens / Closes are minutes after local midnight; spans past
+// midnight are split and the part after midnight has DayOffset 1. A closed exception day is a
+// row with Opens = Closes = 0.
+type PlaceOpeningInterval struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"place_id"`
+	Weekday   int        `gorm:"not null" json:"weekday"`
+	Date      *time.Time `gorm:"type:date" json:"date,omitempty"`
+	DayOffset int        `gorm:"not null" json:"day_offset"`
+	Opens     int        `gorm:"not null" json:"opens"`
+	Closes    int        `gorm:"not null" json:"closes"`
+}
+
+// TableName overrides the table name.
+func (PlaceOpeningInterval) TableName() string {
+	return "place_opening_intervals"
+}
+
+// BeforeCreate sets ID if not set.
+func (i *PlaceOpeningInterval) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -40,20 +40,24 @@ func (j *DetailsJSON) Scan(value interface{}) error {
 }
 
 type Place struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
-	Name        string         `gorm:"size:255;not null" json:"name"`
-	Name_local  string         `gorm:"size:100;not null;index;default:''" json:"name_local"`
-	Description string         `gorm:"type:text" json:"description"`
-	Address     string         `gorm:"size:512" json:"address"`
-	Latitude    float64        `json:"latitude"`
-	Longitude   float64        `json:"longitude"`
-	Details     DetailsJSON    `gorm:"type:jsonb" json:"details"`
-	PlaceTypeID uuid.UUID      `gorm:"type:uuid;not null;index" json:"place_type_id"`
-	OwnerID     *uuid.UUID     `gorm:"type:uuid;index" json:"owner_id,omitempty"`
-	IsVerified  bool           `gorm:"default:false" json:"is_verified"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                 uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Name               string         `gorm:"size:255;not null" json:"name"`
+	Name_local         string         `gorm:"size:100;not null;index;default:''" json:"name_local"`
+	Description        string         `gorm:"type:text" json:"description"`
+	Address            string         `gorm:"size:512" json:"address"`
+	Latitude           float64        `json:"latitude"`
+	Longitude          float64        `json:"longitude"`
+	Details            DetailsJSON    `gorm:"type:jsonb" json:"details"`
+	PlaceTypeID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"place_type_id"`
+	OwnerID            *uuid.UUID     `gorm:"type:uuid;index" json:"owner_id,omitempty"`
+	IsVerified         bool           `gorm:"default:false" json:"is_verified"` // mirrors VerificationStatus == verified
+	VerificationStatus string         `gorm:"size:20;not null;default:'unverified';index" json:"verification_status"`
+	RatingAverage      float64        `gorm:"not null;default:0" json:"rating_average"` // mean of PlaceReview.Rating
+	RatingCount        int            `gorm:"not null;default:0" json:"rating_count"`
+	HiddenAt           *time.Time     `gorm:"index" json:"hidden_at,omitempty"` // hidden by moderation
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
 
 	PlaceType *PlaceType `gorm:"foreignKey:PlaceTypeID" json:"place_type,omitempty"`
 	Owner     *User      `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place claim statuses.
+const (
+	ClaimPending  = "pending"
+	ClaimApproved = "approved"
+	ClaimRejected = "rejected"
+)
+
+// Ownership change reasons.
+const (
+	OwnershipClaim    = "claim"
+	OwnershipTransfer = "transfer"
+)
+
+// PlaceClaimRequest is a business owner's request to take ownership of an existing place,
+// with notes and links to proof documents. Reviewed by an admin.
+type PlaceClaimRequest struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"place_id"`
+	ClaimantID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"claimant_id"`
+	Notes       string         `gorm:"type:text" json:"notes"`
+	ProofURLs   StringListJSON `gorm:"type:jsonb" json:"proof_urls"`
+	Status      string         `gorm:"size:20;not null;index" json:"status"` // pending | approved | rejected
+	ReviewerID  *uuid.UUID     `gorm:"type:uuid" json:"reviewer_id,omitempty"`
+	ReviewNotes string         `gorm:"type:text" json:"review_notes"`
+	ReviewedAt  *time.Time     `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	Place *Place `gorm:"foreignKey:PlaceID" json:"place,omitempty"`
+}
+
+// TableName overrides the table name.
+func (PlaceClaimRequest) TableName() string {
+	return "place_claim_requests"
+}
+
+// BeforeCreate sets ID if not set.
+func (r *PlaceClaimRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// PlaceOwnershipChange records each change of Place.OwnerID (append-only).
+type PlaceOwnershipChange struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID     uuid.UUID  `gorm:"type:uuid;not null;index:idx_place_ownership_place_created" json:"place_id"`
+	FromOwnerID *uuid.UUID `gorm:"type:uuid" json:"from_owner_id,omitempty"`
+	ToOwnerID   *uuid.UUID `gorm:"type:uuid" json:"to_owner_id,omitempty"`
+	ActorID     uuid.UUID  `gorm:"type:uuid;not null" json:"actor_id"`
+	Reason      string     `gorm:"size:20;not null" json:"reason"` // claim | transfer
+	ClaimID     *uuid.UUID `gorm:"type:uuid" json:"claim_id,omitempty"`
+	Notes       string     `gorm:"type:text" json:"notes,omitempty"`
+	// ImpersonatorID is the real actor when ActorID was acting under an impersonation token.
+	ImpersonatorID *uuid.UUID `gorm:"type:uuid" json:"impersonator_id,omitempty"`
+	CreatedAt      time.Time  `gorm:"index:idx_place_ownership_place_created" json:"created_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceOwnershipChange) TableName() string {
+	return "place_ownership_changes"
+}
+
+// BeforeCreate sets ID if not set and ImpersonatorID from the statement context (see ImpersonatorFrom).
+func (c *PlaceOwnershipChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	if c.ImpersonatorID == nil {
+		c.ImpersonatorID = ImpersonatorFrom(tx)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Edit suggestion statuses.
+const (
+	SuggestionPending  = "pending"
+	SuggestionAccepted = "accepted"
+	SuggestionRejected = "rejected"
+)
+
+// PlaceChangesJSON maps place fields to values (JSONB). For "details" the value is itself a map
+// of detail keys; a null detail value removes the key.
+type PlaceChangesJSON map[string]interface{}
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j PlaceChangesJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *PlaceChangesJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("place changes: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// PlaceEditSuggestion is a community-proposed diff to a place. Base holds the values of the
+// changed fields when the suggestion was made, so accepting it can detect conflicting edits.
+type PlaceEditSuggestion struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID     uuid.UUID        `gorm:"type:uuid;not null;index" json:"place_id"`
+	AuthorID    uuid.UUID        `gorm:"type:uuid;not null;index" json:"author_id"`
+	Changes     PlaceChangesJSON `gorm:"type:jsonb;not null" json:"changes"`
+	Base        PlaceChangesJSON `gorm:"type:jsonb;not null" json:"base"`
+	Comment     string           `gorm:"type:text" json:"comment"`
+	Status      string           `gorm:"size:20;not null;index" json:"status"` // pending | accepted | rejected
+	ReviewerID  *uuid.UUID       `gorm:"type:uuid" json:"reviewer_id,omitempty"`
+	ReviewNotes string           `gorm:"type:text" json:"review_notes"`
+	ReviewedAt  *time.Time       `json:"reviewed_at,omitempty"`
+	// ImpersonatorID and ReviewerImpersonatorID are the real actors when AuthorID / ReviewerID
+	// were acting under an impersonation token.
+	ImpersonatorID         *uuid.UUID `gorm:"type:uuid" json:"impersonator_id,omitempty"`
+	ReviewerImpersonatorID *uuid.UUID `gorm:"type:uuid" json:"reviewer_impersonator_id,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceEditSuggestion) TableName() string {
+	return "place_edit_suggestions"
+}
+
+// BeforeCreate sets ID if not set and ImpersonatorID from the statement context (see ImpersonatorFrom).
+func (s *PlaceEditSuggestion) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	if s.ImpersonatorID == nil {
+		s.ImpersonatorID = ImpersonatorFrom(tx)
+	}
+	return nil
+}
+
+// ContributorStats holds per-user counters for community edit suggestions.
+// Reputation grows with accepted suggestions.
+type ContributorStats struct {
+	UserID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Submitted  int       `gorm:"not null;default:0" json:"submitted"`
+	Accepted   int       `gorm:"not null;default:0" json:"accepted"`
+	Rejected   int       `gorm:"not null;default:0" json:"rejected"`
+	Reputation int       `gorm:"not null;default:0;index" json:"reputation"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (ContributorStats) TableName() string {
+	return "contributor_stats"
+}
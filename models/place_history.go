@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceHistory actions.
+const (
+	PlaceHistoryCreate              = "create"
+	PlaceHistoryVerificationRequest = "verification_request"
+	PlaceHistoryVerify              = "verify"
+	PlaceHistoryReject              = "reject"
+	PlaceHistoryClaimRequest        = "claim_request"
+	PlaceHistoryClaimReject         = "claim_reject"
+	PlaceHistoryOwnerChange         = "owner_change"
+	PlaceHistoryEdit                = "edit"
+	PlaceHistoryDelete              = "delete"
+	PlaceHistoryRestore             = "restore"
+	PlaceHistoryMerge               = "merge"
+	PlaceHistoryOpeningHours        = "opening_hours"
+)
+
+// PlaceHistory records every state change of a place (append-only). place_id is not a FK so
+// the history survives hard deletion of the place.
+type PlaceHistory struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_place_history_place_created" json:"place_id"`
+	ActorID    *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Action     string     `gorm:"size:30;not null" json:"action"` // see PlaceHistory actions
+	FromStatus string     `gorm:"size:20" json:"from_status,omitempty"`
+	ToStatus   string     `gorm:"size:20" json:"to_status,omitempty"`
+	Notes      string     `gorm:"type:text" json:"notes,omitempty"`
+	// ImpersonatorID is the real actor when ActorID was acting under an impersonation token.
+	ImpersonatorID *uuid.UUID `gorm:"type:uuid" json:"impersonator_id,omitempty"`
+	CreatedAt      time.Time  `gorm:"index:idx_place_history_place_created" json:"created_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceHistory) TableName() string {
+	return "place_histories"
+}
+
+// BeforeCreate sets ID if not set and ImpersonatorID from the statement context (see ImpersonatorFrom).
+func (h *PlaceHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	if h.ImpersonatorID == nil {
+		h.ImpersonatorID = ImpersonatorFrom(tx)
+	}
+	return nil
+}
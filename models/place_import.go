@@ -0,0 +1,128 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place import statuses.
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// Place import formats.
+const (
+	ImportFormatCSV     = "csv"
+	ImportFormatGeoJSON = "geojson"
+)
+
+// ImportRowError is one entry of an import's error report. Row is the 1-based data row
+// (CSV line after the header, or GeoJSON feature index).
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportErrorsJSON is the per-row error report of an import (JSONB).
+type ImportErrorsJSON []ImportRowError
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j ImportErrorsJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return "[]", nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *ImportErrorsJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("import errors: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// ImportMappingJSON maps import targets (place fields or "details.<key>") to source CSV columns
+// or GeoJSON properties (JSONB).
+type ImportMappingJSON map[string]string
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j ImportMappingJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *ImportMappingJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("import mapping: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// PlaceImport is a bulk place import job. The uploaded file is kept in Payload until the job
+// finishes. In a dry run rows are validated but nothing is created; SucceededRows then counts
+// the rows that would have been created.
+type PlaceImport struct {
+	ID            uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	PlaceTypeID   uuid.UUID         `gorm:"type:uuid;not null" json:"place_type_id"`
+	Format        string            `gorm:"size:10;not null" json:"format"` // csv | geojson
+	Mapping       ImportMappingJSON `gorm:"type:jsonb" json:"mapping,omitempty"`
+	DryRun        bool              `gorm:"not null" json:"dry_run"`
+	SkipDuplicate bool              `gorm:"not null" json:"skip_duplicate_check"`
+	Status        string            `gorm:"size:20;not null;index" json:"status"` // pending | running | completed | failed
+	Payload       []byte            `gorm:"type:bytea" json:"-"`
+	TotalRows     int               `gorm:"not null;default:0" json:"total_rows"`
+	ProcessedRows int               `gorm:"not null;default:0" json:"processed_rows"`
+	SucceededRows int               `gorm:"not null;default:0" json:"succeeded_rows"`
+	FailedRows    int               `gorm:"not null;default:0" json:"failed_rows"`
+	Errors        ImportErrorsJSON  `gorm:"type:jsonb" json:"errors"`
+	Error         string            `gorm:"type:text" json:"error,omitempty"` // set when the whole job failed
+	CompletedAt   *time.Time        `json:"completed_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceImport) TableName() string {
+	return "place_imports"
+}
+
+// BeforeCreate sets ID if not set.
+func (i *PlaceImport) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
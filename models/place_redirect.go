@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaceRedirect points a merged (soft-deleted) place ID at the place it was merged into.
+// Redirects are kept flat: merging a survivor later repoints its redirects to the new survivor.
+type PlaceRedirect struct {
+	FromID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"from_id"`
+	ToID      uuid.UUID `gorm:"type:uuid;not null;index" json:"to_id"`
+	MergedBy  uuid.UUID `gorm:"type:uuid;not null" json:"merged_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceRedirect) TableName() string {
+	return "place_redirects"
+}
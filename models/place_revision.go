@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place revision actions.
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+)
+
+// PlaceRevision is an immutable snapshot of a place's content after a change. Number counts up
+// from 1 per place. Snapshot holds the editable fields (see services.editablePlaceFields) plus
+// place_type_id.
+type PlaceRevision struct {
+	ID       uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID  uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_place_revision_number" json:"place_id"`
+	Number   int              `gorm:"not null;uniqueIndex:idx_place_revision_number" json:"number"`
+	AuthorID *uuid.UUID       `gorm:"type:uuid" json:"author_id,omitempty"`
+	Action   string           `gorm:"size:20;not null" json:"action"` // create | update | delete | restore
+	Snapshot PlaceChangesJSON `gorm:"type:jsonb;not null" json:"snapshot"`
+	// ImpersonatorID is the real actor when AuthorID was acting under an impersonation token.
+	ImpersonatorID *uuid.UUID `gorm:"type:uuid" json:"impersonator_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceRevision) TableName() string {
+	return "place_revisions"
+}
+
+// BeforeCreate sets ID if not set and ImpersonatorID from the statement context (see ImpersonatorFrom).
+func (r *PlaceRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.ImpersonatorID == nil {
+		r.ImpersonatorID = ImpersonatorFrom(tx)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place verification statuses (Place.VerificationStatus). Place.IsVerified mirrors "verified".
+const (
+	PlaceUnverified = "unverified"
+	PlacePending    = "pending"
+	PlaceVerified   = "verified"
+	PlaceRejected   = "rejected"
+)
+
+// Verification request statuses.
+const (
+	VerificationPending  = "pending"
+	VerificationApproved = "approved"
+	VerificationRejected = "rejected"
+)
+
+// StringListJSON is a JSONB array of strings.
+type StringListJSON []string
+
+// Value implements driver.Valuer for GORM JSONB.
+func (j StringListJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return "[]", nil
+	}
+	return json.Marshal(j)
+}
+
+// Scan implements sql.Scanner for GORM JSONB.
+func (j *StringListJSON) Scan(value interface{}) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("string list: unsupported type")
+	}
+	return json.Unmarshal(b, j)
+}
+
+// PlaceVerificationRequest asks a reviewer with places:verify to verify a place, with evidence
+// (free text plus links, e.g. photos or a business registration).
+type PlaceVerificationRequest struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"place_id"`
+	RequestedBy  uuid.UUID      `gorm:"type:uuid;not null" json:"requested_by"`
+	Evidence     string         `gorm:"type:text" json:"evidence"`
+	EvidenceURLs StringListJSON `gorm:"type:jsonb" json:"evidence_urls"`
+	Status       string         `gorm:"size:20;not null;index" json:"status"` // pending | approved | rejected
+	ReviewerID   *uuid.UUID     `gorm:"type:uuid" json:"reviewer_id,omitempty"`
+	ReviewNotes  string         `gorm:"type:text" json:"review_notes"`
+	ReviewedAt   *time.Time     `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+
+	Place *Place `gorm:"foreignKey:PlaceID" json:"place,omitempty"`
+}
+
+// TableName overrides the table name.
+func (PlaceVerificationRequest) TableName() string {
+	return "place_verification_requests"
+}
+
+// BeforeCreate sets ID if not set.
+func (r *PlaceVerificationRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ type Plan struct {
 	CreatorID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"creator_id"`
 	Visibility  PlanVisibility `gorm:"type:varchar(20);default:'Public'" json:"visibility"`
 	IsTemplate  bool           `gorm:"default:false" json:"is_template"`
+	HiddenAt    *time.Time     `gorm:"index" json:"hidden_at,omitempty"` // hidden by moderation
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Review rating bounds.
+const (
+	ReviewRatingMin = 1
+	ReviewRatingMax = 5
+)
+
+// PlaceReview is a user's review of a place (one per user and place), with the owner's public reply.
+// Place.RatingAverage and Place.RatingCount are kept in step with the visible reviews.
+type PlaceReview struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaceID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_place_review_author;index:idx_place_review_place_created" json:"place_id"`
+	AuthorID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_place_review_author;index" json:"author_id"`
+	Rating        int        `gorm:"not null" json:"rating"` // 1..5
+	Text          string     `gorm:"type:text;not null;default:''" json:"text"`
+	VisitedOn     *time.Time `gorm:"type:date" json:"visited_on,omitempty"`
+	Reply         string     `gorm:"type:text;not null;default:''" json:"reply,omitempty"`
+	ReplyAuthorID *uuid.UUID `gorm:"type:uuid" json:"reply_author_id,omitempty"`
+	RepliedAt     *time.Time `json:"replied_at,omitempty"`
+	HiddenAt      *time.Time `gorm:"index" json:"hidden_at,omitempty"` // hidden by moderation
+	CreatedAt     time.Time  `gorm:"index:idx_place_review_place_created" json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (PlaceReview) TableName() string {
+	return "place_reviews"
+}
+
+// BeforeCreate sets ID if not set.
+func (r *PlaceReview) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
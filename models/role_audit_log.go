@@ -7,22 +7,59 @@ import (
 	"gorm.io/gorm"
 )
 
-// AuditAction is assign or remove.
+// AuditAction values. request / approve / reject track two-person grants of sensitive roles;
+// role_create / role_update / role_delete track role definition changes (TargetUserID is NULL);
+// impersonate records an admin starting an impersonation session of TargetUserID; user_* track account
+// suspension, soft-deletion and admin-managed attributes, user_erase_request / user_erase the self-service erasure of an
+// account (RoleID is uuid.Nil for all of them).
 const (
-	AuditActionAssign = "assign"
-	AuditActionRemove = "remove"
+	AuditActionAssign      = "assign"
+	AuditActionRemove      = "remove"
+	AuditActionRequest     = "request"
+	AuditActionApprove     = "approve"
+	AuditActionReject      = "reject"
+	AuditActionRoleCreate  = "role_create"
+	AuditActionRoleUpdate  = "role_update"
+	AuditActionRoleDelete  = "role_delete"
+	AuditActionImpersonate = "impersonate"
+	AuditActionSuspend     = "user_suspend"
+	AuditActionUnsuspend   = "user_unsuspend"
+	AuditActionUserDelete  = "user_delete"
+	AuditActionUserRestore = "user_restore"
+	AuditActionUserUpdate  = "user_update"
+	AuditActionErasure     = "user_erase_request"
+	AuditActionUserErase   = "user_erase"
 )
 
+// ImpersonatorContextKey is the context key (also the Fiber Locals key) holding the real actor's
+// uuid.UUID while a request runs under an impersonation token.
+const ImpersonatorContextKey = "impersonatorID"
+
+// ImpersonatorFrom returns the real actor stored under ImpersonatorContextKey in the statement
+// context, or nil outside an impersonated request.
+func ImpersonatorFrom(tx *gorm.DB) *uuid.UUID {
+	if tx.Statement.Context == nil {
+		return nil
+	}
+	if id, ok := tx.Statement.Context.Value(ImpersonatorContextKey).(uuid.UUID); ok && id != uuid.Nil {
+		return &id
+	}
+	return nil
+}
+
 // RoleAuditLog records each role-assignment change (append-only).
 // role_id is not a FK so the record survives role deletion.
 type RoleAuditLog struct {
-	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	ActorID      uuid.UUID `gorm:"type:uuid;not null;index:idx_role_audit_target_user" json:"actor_id"`
-	Action       string    `gorm:"size:20;not null" json:"action"` // assign | remove
-	TargetUserID uuid.UUID `gorm:"type:uuid;not null;index:idx_role_audit_target_user" json:"target_user_id"`
-	RoleID       uuid.UUID `gorm:"type:uuid;not null" json:"role_id"`
-	RoleSlug     string    `gorm:"size:100;not null" json:"role_slug"`
-	CreatedAt    time.Time `gorm:"index:idx_role_audit_created_at" json:"created_at"`
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ActorID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_role_audit_target_user" json:"actor_id"`
+	Action       string     `gorm:"size:20;not null" json:"action"` // see AuditAction values
+	TargetUserID *uuid.UUID `gorm:"type:uuid;index:idx_role_audit_target_user" json:"target_user_id"`
+	RoleID       uuid.UUID  `gorm:"type:uuid;not null" json:"role_id"`
+	RoleSlug     string     `gorm:"size:100;not null" json:"role_slug"`
+	Detail       string     `gorm:"type:text" json:"detail,omitempty"` // e.g. permission diff for role_update
+	// ImpersonatorID is the real actor when ActorID was acting under an impersonation token.
+	ImpersonatorID *uuid.UUID `gorm:"type:uuid;index" json:"impersonator_id,omitempty"`
+	CreatedAt      time.Time  `gorm:"index:idx_role_audit_created_at" json:"created_at"`
 }
 
 // TableName overrides the table name.
@@ -30,11 +67,15 @@ func (RoleAuditLog) TableName() string {
 	return "role_audit_logs"
 }
 
-// BeforeCreate sets ID and CreatedAt if not set.
+// BeforeCreate sets ID and CreatedAt if not set, and ImpersonatorID from the statement context
+// so every entry written during an impersonated request is tagged with the real actor.
 func (ral *RoleAuditLog) BeforeCreate(tx *gorm.DB) error {
 	if ral.ID == uuid.Nil {
 		ral.ID = uuid.New()
 	}
+	if ral.ImpersonatorID == nil {
+		ral.ImpersonatorID = ImpersonatorFrom(tx)
+	}
 	if ral.CreatedAt.IsZero() {
 		ral.CreatedAt = time.Now()
 	}
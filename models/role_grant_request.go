@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Grant request statuses.
+const (
+	GrantStatusPending  = "pending"
+	GrantStatusApproved = "approved"
+	GrantStatusRejected = "rejected"
+	GrantStatusExpired  = "expired"
+)
+
+// RoleGrantRequest is a pending assignment of a sensitive role that a second admin must approve.
+// role_id is not a FK so the record survives role deletion.
+type RoleGrantRequest struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TargetUserID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_role_grant_target_role" json:"target_user_id"`
+	RoleID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_role_grant_target_role" json:"role_id"`
+	RoleSlug       string     `gorm:"size:100;not null" json:"role_slug"`
+	RequestedBy    uuid.UUID  `gorm:"type:uuid;not null" json:"requested_by"`
+	RequestReason  string     `gorm:"type:text" json:"request_reason"`
+	Status         string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending | approved | rejected | expired
+	DecidedBy      *uuid.UUID `gorm:"type:uuid" json:"decided_by,omitempty"`
+	DecisionReason string     `gorm:"type:text" json:"decision_reason"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (RoleGrantRequest) TableName() string {
+	return "role_grant_requests"
+}
+
+// BeforeCreate sets ID if not set.
+func (r *RoleGrantRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
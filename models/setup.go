@@ -7,13 +7,35 @@ import "gorm.io/gorm"
 func MigrateAll(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&User{},
+		&EmailVerification{},
+		&DataExport{},
 		&Role{},
 		&UserRole{},
 		&RolePermission{},
 		&RoleAuditLog{},
+		&RoleGrantRequest{},
+		&AccessPolicy{},
 		&PlaceType{},
 		&Place{},
+		&PlaceVerificationRequest{},
+		&PlaceHistory{},
+		&PlaceRevision{},
+		&PlaceRedirect{},
+		&PlaceImport{},
+		&PlaceOpeningHours{},
+		&PlaceOpeningInterval{},
+		&Translation{},
+		&PlaceClaimRequest{},
+		&PlaceOwnershipChange{},
+		&PlaceEditSuggestion{},
+		&PlaceReview{},
+		&ContentReport{},
+		&ModerationAction{},
+		&ContributorStats{},
 		&Plan{},
 		&PlanItem{},
+		&StatRollup{},
+		&StatSnapshot{},
+		&UserActivityDay{},
 	)
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stat rollup metrics (daily series) and snapshot metrics (current totals).
+const (
+	StatSignups       = "signups"
+	StatPlacesCreated = "places_created" // dimension: place type slug
+	StatPlansCreated  = "plans_created"  // dimension: visibility
+	StatPlaces        = "places"         // snapshot; dimension: "<type slug>:verified|unverified"
+	StatRoleMembers   = "role_members"   // snapshot; dimension: role slug
+	StatUsers         = "users"          // snapshot; dimension: active | suspended | deleted
+)
+
+// StatRollup is one daily aggregate, rebuilt by the stats worker for recent days.
+type StatRollup struct {
+	Day       time.Time `gorm:"type:date;primaryKey" json:"day"`
+	Metric    string    `gorm:"size:50;primaryKey" json:"metric"`
+	Dimension string    `gorm:"size:100;primaryKey" json:"dimension"`
+	Value     int64     `gorm:"not null" json:"value"`
+}
+
+// TableName overrides the table name.
+func (StatRollup) TableName() string {
+	return "stat_rollups"
+}
+
+// StatSnapshot is a point-in-time total, replaced on every stats refresh.
+type StatSnapshot struct {
+	Metric      string    `gorm:"size:50;primaryKey" json:"metric"`
+	Dimension   string    `gorm:"size:100;primaryKey" json:"dimension"`
+	Value       int64     `gorm:"not null" json:"value"`
+	RefreshedAt time.Time `gorm:"not null" json:"refreshed_at"`
+}
+
+// TableName overrides the table name.
+func (StatSnapshot) TableName() string {
+	return "stat_snapshots"
+}
+
+// UserActivityDay records that a user made at least one authenticated request on Day (UTC).
+type UserActivityDay struct {
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Day    time.Time `gorm:"type:date;primaryKey;index" json:"day"`
+}
+
+// TableName overrides the table name.
+func (UserActivityDay) TableName() string {
+	return "user_activity_days"
+}
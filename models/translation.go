@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Translation entity types.
+const (
+	TranslationPlace     = "place"
+	TranslationPlaceType = "place_type"
+)
+
+// Translation is one field of a place or place type in one locale. Fields are "name",
+// "description" (places) and "form.<key>" for the label of a place type's form field.
+type Translation struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	EntityType string     `gorm:"size:20;not null;uniqueIndex:idx_translation_key" json:"entity_type"`
+	EntityID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_translation_key" json:"entity_id"`
+	Field      string     `gorm:"size:100;not null;uniqueIndex:idx_translation_key" json:"field"`
+	Locale     string     `gorm:"size:20;not null;uniqueIndex:idx_translation_key;index" json:"locale"`
+	Value      string     `gorm:"type:text;not null" json:"value"`
+	UpdatedBy  *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name.
+func (Translation) TableName() string {
+	return "translations"
+}
+
+// BeforeCreate sets ID if not set.
+func (t *Translation) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -8,18 +8,25 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
-	Username     string         `gorm:"size:100;not null;index" json:"username"`
-	Name         string         `gorm:"size:100;not null;index;default:''" json:"name"`
-	Name_local   string         `gorm:"size:100;not null;index;default:''" json:"name_local"`
-	DateOfBirth  time.Time      `gorm:"not null;index;default:1970-01-01 00:00:00" json:"date_of_birth"`
-	Gender       string         `gorm:"size:50;not null;index;default:''" json:"gender"`
-	Email        string         `gorm:"size:255;not null;uniqueIndex" json:"email"`
-	PasswordHash string         `gorm:"size:255;not null" json:"-"`
-	AvatarURL    string         `gorm:"size:512" json:"avatar_url,omitempty"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Username          string         `gorm:"size:100;not null;index" json:"username"`
+	Name              string         `gorm:"size:100;not null;index;default:''" json:"name"`
+	Name_local        string         `gorm:"size:100;not null;index;default:''" json:"name_local"`
+	DateOfBirth       time.Time      `gorm:"not null;index;default:1970-01-01 00:00:00" json:"date_of_birth"`
+	Gender            string         `gorm:"size:50;not null;index;default:''" json:"gender"`
+	Email             string         `gorm:"size:255;not null;uniqueIndex" json:"email"`
+	PasswordHash      string         `gorm:"size:255;not null" json:"-"`
+	AvatarURL         string         `gorm:"size:512" json:"avatar_url,omitempty"`
+	City              string         `gorm:"size:100;not null;index;default:''" json:"city"` // set by admins; subject.city in access policies
+	UsernameChangedAt *time.Time     `json:"username_changed_at,omitempty"`
+	SuspendedAt       *time.Time     `gorm:"index" json:"suspended_at,omitempty"`
+	SuspendedUntil    *time.Time     `json:"suspended_until,omitempty"` // nil = suspended indefinitely
+	SuspensionReason  string         `gorm:"type:text" json:"suspension_reason,omitempty"`
+	ErasureDueAt      *time.Time     `gorm:"index" json:"erasure_due_at,omitempty"` // set by DELETE /api/me
+	ErasedAt          *time.Time     `json:"erased_at,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	Places []Place `gorm:"foreignKey:OwnerID" json:"-"`
 	Plans  []Plan  `gorm:"foreignKey:CreatorID" json:"-"`
@@ -33,6 +40,11 @@ func (User) TableName() string {
 	return "users"
 }
 
+// IsSuspended reports whether the user is suspended at time now.
+func (u *User) IsSuspended(now time.Time) bool {
+	return u.SuspendedAt != nil && (u.SuspendedUntil == nil || u.SuspendedUntil.After(now))
+}
+
 // BeforeCreate ensures ID is set (roles are assigned via user_roles).
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == uuid.Nil {
@@ -2,18 +2,24 @@ package permissions
 
 // Permission keys (fixed catalog). Use these when checking or assigning permissions.
 const (
-	PlacesRead      = "places:read"
-	PlacesWrite     = "places:write"
-	PlacesOwn       = "places:own" // can write only places owned by the user
-	PlacesDelete    = "places:delete"
-	PlaceTypesRead  = "place_types:read"
-	PlaceTypesWrite = "place_types:write"
-	PlansRead       = "plans:read"
-	PlansWrite      = "plans:write"
-	PlansDelete     = "plans:delete"
-	UsersRead       = "users:read"
-	UsersWrite      = "users:write"
-	RolesManage     = "roles:manage"
+	PlacesRead       = "places:read"
+	PlacesWrite      = "places:write"
+	PlacesOwn        = "places:own" // can write only places owned by the user
+	PlacesDelete     = "places:delete"
+	PlacesVerify     = "places:verify" // review place verification requests
+	PlacesImport     = "places:import" // bulk import places from CSV / GeoJSON
+	PlaceTypesRead   = "place_types:read"
+	PlaceTypesWrite  = "place_types:write"
+	PlansRead        = "plans:read"
+	PlansWrite       = "plans:write"
+	PlansDelete      = "plans:delete"
+	ReviewsRead      = "reviews:read"
+	ReviewsWrite     = "reviews:write"     // write, edit and delete your own reviews; owners reply to reviews of their places
+	ReviewsDelete    = "reviews:delete"    // delete anyone's review
+	ModerationManage = "moderation:manage" // work the report queue: hide, delete, warn, suspend authors
+	UsersRead        = "users:read"
+	UsersWrite       = "users:write"
+	RolesManage      = "roles:manage"
 )
 
 // DTO is the response shape for one permission (key, resource, action, description).
@@ -31,11 +37,17 @@ func All() []DTO {
 		{Key: PlacesWrite, Resource: "places", Action: "write", Description: "Create / edit places"},
 		{Key: PlacesOwn, Resource: "places", Action: "own", Description: "Edit only places you own"},
 		{Key: PlacesDelete, Resource: "places", Action: "delete", Description: "Delete places"},
+		{Key: PlacesVerify, Resource: "places", Action: "verify", Description: "Approve / reject place verification requests"},
+		{Key: PlacesImport, Resource: "places", Action: "import", Description: "Bulk import places from CSV / GeoJSON"},
 		{Key: PlaceTypesRead, Resource: "place_types", Action: "read", Description: "View place types"},
 		{Key: PlaceTypesWrite, Resource: "place_types", Action: "write", Description: "Create / edit place types"},
 		{Key: PlansRead, Resource: "plans", Action: "read", Description: "View plans"},
 		{Key: PlansWrite, Resource: "plans", Action: "write", Description: "Create / edit plans"},
 		{Key: PlansDelete, Resource: "plans", Action: "delete", Description: "Delete plans"},
+		{Key: ReviewsRead, Resource: "reviews", Action: "read", Description: "View place reviews"},
+		{Key: ReviewsWrite, Resource: "reviews", Action: "write", Description: "Review places and reply to reviews of places you own"},
+		{Key: ReviewsDelete, Resource: "reviews", Action: "delete", Description: "Delete any review"},
+		{Key: ModerationManage, Resource: "moderation", Action: "manage", Description: "Review reported content and hide, delete, warn or suspend its author"},
 		{Key: UsersRead, Resource: "users", Action: "read", Description: "View user profiles"},
 		{Key: UsersWrite, Resource: "users", Action: "write", Description: "Edit user profiles"},
 		{Key: RolesManage, Resource: "roles", Action: "manage", Description: "Create, update, delete roles and assign roles to users"},
@@ -45,9 +57,11 @@ func All() []DTO {
 // AllKeys returns all permission keys for validation and seeding.
 func AllKeys() []string {
 	return []string{
-		PlacesRead, PlacesWrite, PlacesOwn, PlacesDelete,
+		PlacesRead, PlacesWrite, PlacesOwn, PlacesDelete, PlacesVerify, PlacesImport,
 		PlaceTypesRead, PlaceTypesWrite,
 		PlansRead, PlansWrite, PlansDelete,
+		ReviewsRead, ReviewsWrite, ReviewsDelete,
+		ModerationManage,
 		UsersRead, UsersWrite,
 		RolesManage,
 	}
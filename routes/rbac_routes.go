@@ -17,14 +17,27 @@ func SetupRBAC(api fiber.Router, db *gorm.DB) {
 	admin.Get("/users/:id/roles", handlers.ListUserRoles(db))
 	admin.Post("/users/:id/roles", handlers.AssignRole(db))
 	admin.Delete("/users/:id/roles/:roleId", handlers.UnassignRole(db))
-	// Audit (more specific before /roles/:id)
+	// Audit and grants (more specific before /roles/:id)
 	admin.Get("/roles/audit", handlers.ListRoleAudit(db))
+	// Two-person approval of sensitive role grants
+	admin.Get("/roles/grants", handlers.ListRoleGrants(db))
+	admin.Post("/roles/grants/:id/approve", handlers.ApproveRoleGrant(db))
+	admin.Post("/roles/grants/:id/reject", handlers.RejectRoleGrant(db))
 	// Permissions catalog
 	admin.Get("/permissions", handlers.ListPermissions())
 	// Role CRUD
 	admin.Get("/roles", handlers.ListRoles(db))
 	admin.Post("/roles", handlers.CreateRole(db))
 	admin.Get("/roles/:id", handlers.GetRole(db))
+	admin.Get("/roles/:id/members", handlers.ListRoleMembers(db))
+	admin.Post("/roles/:id/members\\:batch", handlers.BatchRoleMembers(db))
 	admin.Put("/roles/:id", handlers.UpdateRole(db))
 	admin.Delete("/roles/:id", handlers.DeleteRole(db))
+	// Attribute-based access policies (evaluate before /policies/:id)
+	admin.Post("/policies/evaluate", handlers.EvaluateAccessPolicies(db))
+	admin.Get("/policies", handlers.ListAccessPolicies(db))
+	admin.Post("/policies", handlers.CreateAccessPolicy(db))
+	admin.Get("/policies/:id", handlers.GetAccessPolicy(db))
+	admin.Put("/policies/:id", handlers.UpdateAccessPolicy(db))
+	admin.Delete("/policies/:id", handlers.DeleteAccessPolicy(db))
 }
@@ -5,6 +5,7 @@ import (
 
 	"ducksrow/backend/handlers"
 	"ducksrow/backend/middleware"
+	"ducksrow/backend/models"
 	"ducksrow/backend/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,6 +17,9 @@ func Setup(app *fiber.App, db *gorm.DB) {
 	jwtSecret := os.Getenv("JWT_SECRET")
 	authSvc := services.NewAuthService(db)
 
+	// Response language (Accept-Language) for every route
+	app.Use(middleware.Locale())
+
 	// Health
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "ok"})
@@ -26,13 +30,110 @@ func Setup(app *fiber.App, db *gorm.DB) {
 	app.Post("/auth/login", handlers.Login(authSvc, jwtSecret))
 	app.Post("/auth/logout", handlers.Logout())
 
+	// Data export download (public; authorised by the signed link)
+	app.Get("/exports/:id/download", handlers.DownloadDataExport(db))
+
 	// Protected routes (require auth + permission per route)
 	api := app.Group("/api", middleware.Protected(db))
-	api.Post("/places", middleware.RequirePermission(db, "places:write"), handlers.CreatePlace(db))
-	// Place update/delete (when added): use middleware.RequireOwnershipOrPermission(permSvc, ownerSvc, "places:write", "places:own", "id")
+	api.Post("/places",
+		middleware.RequirePermission(db, "places:write"),
+		middleware.RequirePolicy(db, "places:write", "place", ""),
+		handlers.CreatePlace(db))
+	// Place verification workflow and history
+	permSvc := services.NewPermissionService(db)
+	ownerSvc := services.NewPlaceOwnershipService(db)
+	api.Get("/places/verifications", middleware.RequirePermission(db, "places:verify"), handlers.ListPlaceVerifications(db))
+	api.Post("/places/verifications/:id/approve", middleware.RequirePermission(db, "places:verify"), handlers.ApprovePlaceVerification(db))
+	api.Post("/places/verifications/:id/reject", middleware.RequirePermission(db, "places:verify"), handlers.RejectPlaceVerification(db))
+	api.Post("/places/:id/verification",
+		middleware.RequireOwnershipOrPermission(permSvc, ownerSvc, "places:write", "places:own", "id"),
+		handlers.RequestPlaceVerification(db))
+	api.Get("/places/:id/history", middleware.RequirePermission(db, "places:read"), handlers.ListPlaceHistory(db))
+	// Place claims (reviewed by admins) and ownership transfer
+	api.Get("/places/claims", middleware.AdminOnly(db), handlers.ListPlaceClaims(db))
+	api.Post("/places/claims/:id/approve", middleware.AdminOnly(db), handlers.ApprovePlaceClaim(db))
+	api.Post("/places/claims/:id/reject", middleware.AdminOnly(db), handlers.RejectPlaceClaim(db))
+	api.Post("/places/:id/claims", middleware.RequirePermission(db, "places:read"), handlers.ClaimPlace(db))
+	api.Post("/places/:id/transfer",
+		middleware.RequirePermission(db, "places:own"), // current owner or admin, checked by the service
+		middleware.RequirePolicy(db, "places:write", "place", "id"),
+		middleware.NoImpersonation(),
+		handlers.TransferPlace(db))
+	api.Get("/places/:id/ownership", middleware.RequirePermission(db, "places:read"), handlers.ListPlaceOwnership(db))
+	// Community edit suggestions, reviewed by editors (places:write) or the place owner
+	placeReviewer := middleware.RequireOwnershipOrPermission(permSvc, ownerSvc, "places:write", "places:own", "id")
+	placeWritePolicy := middleware.RequirePolicy(db, "places:write", "place", "id")
+	api.Get("/places/suggestions", middleware.RequirePermission(db, "places:write"), handlers.ListPlaceSuggestions(db))
+	api.Post("/places/:id/suggestions", middleware.RequirePermission(db, "places:read"), handlers.SuggestPlaceEdit(db))
+	api.Get("/places/:id/suggestions", placeReviewer, handlers.ListPlaceSuggestions(db))
+	api.Post("/places/:id/suggestions/:sid/accept", placeReviewer, placeWritePolicy, handlers.AcceptPlaceSuggestion(db))
+	api.Post("/places/:id/suggestions/:sid/reject", placeReviewer, handlers.RejectPlaceSuggestion(db))
+	api.Get("/contributors", middleware.RequirePermission(db, "places:read"), handlers.TopContributors(db))
+	// Bulk import (background jobs with progress and a per-row error report)
+	api.Post("/places/imports", middleware.RequirePermission(db, "places:import"), handlers.ImportPlaces(db))
+	api.Get("/places/imports", middleware.RequirePermission(db, "places:import"), handlers.ListPlaceImports(db))
+	api.Get("/places/imports/:id", middleware.RequirePermission(db, "places:import"), handlers.GetPlaceImport(db))
+	// Duplicate report and merging (admins); merged place IDs redirect to the survivor
+	api.Get("/places/duplicates", middleware.AdminOnly(db), handlers.ListPlaceDuplicates(db))
+	api.Post("/places/:id/merge", middleware.AdminOnly(db), placeWritePolicy, handlers.MergePlace(db))
+	// Place listing and export (same filters), read/update/delete and revisions (restore also undeletes)
+	api.Get("/places", middleware.RequirePermission(db, "places:read"), handlers.ListPlaces(db))
+	api.Get("/places/export", middleware.RequirePermission(db, "places:read"), handlers.ExportPlaces(db))
+	api.Get("/places/:id", middleware.RequirePermission(db, "places:read"), handlers.GetPlace(db))
+	api.Patch("/places/:id", placeReviewer, placeWritePolicy, handlers.UpdatePlace(db))
+	api.Delete("/places/:id",
+		middleware.RequirePermission(db, "places:delete"),
+		middleware.RequirePolicy(db, "places:delete", "place", "id"),
+		handlers.DeletePlace(db))
+	api.Get("/places/:id/revisions", middleware.RequirePermission(db, "places:read"), handlers.ListPlaceRevisions(db))
+	api.Post("/places/:id/revisions/:rev/restore", middleware.RequirePermission(db, "places:write"), placeWritePolicy, handlers.RestorePlaceRevision(db))
+	// Opening hours, maintained by editors or the place owner
+	api.Get("/places/:id/opening-hours", middleware.RequirePermission(db, "places:read"), handlers.GetOpeningHours(db))
+	api.Put("/places/:id/opening-hours", placeReviewer, placeWritePolicy, handlers.SetOpeningHours(db))
+	api.Delete("/places/:id/opening-hours", placeReviewer, placeWritePolicy, handlers.DeleteOpeningHours(db))
+	// Translations of place and place type content; reads are localized from Accept-Language
+	api.Get("/places/:id/translations", middleware.RequirePermission(db, "places:read"), handlers.GetTranslations(db, models.TranslationPlace))
+	api.Put("/places/:id/translations", placeReviewer, placeWritePolicy, handlers.SetTranslations(db, models.TranslationPlace))
+	api.Get("/place-types", middleware.RequirePermission(db, "place_types:read"), handlers.ListPlaceTypes(db))
+	api.Get("/place-types/:id", middleware.RequirePermission(db, "place_types:read"), handlers.GetPlaceType(db))
+	api.Get("/place-types/:id/translations", middleware.RequirePermission(db, "place_types:read"), handlers.GetTranslations(db, models.TranslationPlaceType))
+	api.Put("/place-types/:id/translations", middleware.RequirePermission(db, "place_types:write"), handlers.SetTranslations(db, models.TranslationPlaceType))
+	api.Get("/translations/missing", middleware.RequirePermission(db, "places:write"), handlers.ListMissingTranslations(db))
+	// Reviews (one per user and place; the rating aggregate is kept on the place) and owner replies
+	api.Get("/places/:id/reviews", middleware.RequirePermission(db, "reviews:read"), handlers.ListReviews(db))
+	api.Post("/places/:id/reviews", middleware.RequirePermission(db, "reviews:write"), handlers.CreateReview(db))
+	api.Patch("/places/:id/reviews/:rid", middleware.RequirePermission(db, "reviews:write"), handlers.UpdateReview(db))
+	api.Delete("/places/:id/reviews/:rid", middleware.RequirePermission(db, "reviews:write"), handlers.DeleteReview(db))
+	api.Put("/places/:id/reviews/:rid/reply", middleware.RequirePermission(db, "reviews:write"), handlers.ReplyToReview(db))
+	// Abuse reports (any user) and the moderation queue; actions are kept in an audit trail
+	api.Post("/reports", handlers.ReportContent(db))
+	api.Get("/moderation/queue", middleware.RequirePermission(db, "moderation:manage"), handlers.ModerationQueue(db))
+	api.Get("/moderation/reports", middleware.RequirePermission(db, "moderation:manage"), handlers.ListContentReports(db))
+	api.Get("/moderation/actions", middleware.RequirePermission(db, "moderation:manage"), handlers.ListModerationActions(db))
+	api.Post("/moderation/targets/:type/:id/actions", middleware.RequirePermission(db, "moderation:manage"), handlers.ModerateContent(db))
+	// Vector tiles for map clients (requires PostGIS)
+	api.Get("/tiles/places/:z/:x/:y.mvt", middleware.RequirePermission(db, "places:read"), handlers.PlaceTile(db))
+	// Self-service profile
+	api.Get("/me", handlers.GetMe(db))
+	api.Patch("/me", handlers.UpdateMe(db))
+	api.Post("/me/email/verify", middleware.NoImpersonation(), handlers.VerifyEmail(db))
+	api.Post("/me/export", middleware.NoImpersonation(), handlers.RequestDataExport(db))
+	api.Get("/me/export/:id", middleware.NoImpersonation(), handlers.GetDataExport(db))
+	api.Delete("/me", middleware.NoImpersonation(), handlers.DeleteMe(db))
+	api.Post("/me/erasure/cancel", middleware.NoImpersonation(), handlers.CancelDeleteMe(db))
+	// User management (registered before SetupRBAC, whose admin-only group applies to later /api routes)
+	api.Get("/users", middleware.RequirePermission(db, "users:read"), handlers.ListUsers(db))
+	api.Get("/users/:id", middleware.RequirePermission(db, "users:read"), handlers.GetUser(db))
+	api.Patch("/users/:id", middleware.RequirePermission(db, "users:write"), handlers.UpdateUser(db))
+	api.Post("/users/:id/suspend", middleware.RequirePermission(db, "users:write"), handlers.SuspendUser(db))
+	api.Post("/users/:id/unsuspend", middleware.RequirePermission(db, "users:write"), handlers.UnsuspendUser(db))
+	api.Post("/users/:id/restore", middleware.RequirePermission(db, "users:write"), handlers.RestoreUser(db))
+	api.Delete("/users/:id", middleware.RequirePermission(db, "users:write"), handlers.DeleteUser(db))
 	SetupRBAC(api, db)
 
 	// Admin-only routes (user must have admin role via user_roles)
 	admin := app.Group("/admin", middleware.AdminOnly(db))
-	admin.Get("/stats", handlers.AdminStats)
+	admin.Get("/stats", handlers.AdminStats(db))
+	admin.Get("/metrics/permission-cache", handlers.PermissionCacheStats)
+	admin.Post("/users/:id/impersonate", handlers.Impersonate(db, jwtSecret))
 }
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"ducksrow/backend/abac"
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccessPolicyService stores attribute-based access policies and evaluates them with package abac.
+type AccessPolicyService struct {
+	db *gorm.DB
+}
+
+// NewAccessPolicyService returns an AccessPolicyService.
+func NewAccessPolicyService(db *gorm.DB) *AccessPolicyService {
+	return &AccessPolicyService{db: db}
+}
+
+// AccessPolicyInput is the writable part of an access policy.
+type AccessPolicyInput struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Action      string                      `json:"action"`
+	Effect      string                      `json:"effect"`
+	Priority    int                         `json:"priority"`
+	Conditions  models.PolicyConditionsJSON `json:"conditions"`
+	Enabled     *bool                       `json:"enabled"` // default true
+}
+
+// List returns all policies ordered by action and priority.
+func (s *AccessPolicyService) List(ctx context.Context) ([]models.AccessPolicy, error) {
+	var list []models.AccessPolicy
+	err := s.db.WithContext(ctx).Order("action ASC, priority DESC, name ASC").Find(&list).Error
+	return list, err
+}
+
+// Get returns a policy by ID or ErrPolicyNotFound.
+func (s *AccessPolicyService) Get(ctx context.Context, id uuid.UUID) (*models.AccessPolicy, error) {
+	var p models.AccessPolicy
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&p).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPolicyNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Create validates and stores a new policy.
+func (s *AccessPolicyService) Create(ctx context.Context, in AccessPolicyInput) (*models.AccessPolicy, error) {
+	p := models.AccessPolicy{Enabled: true}
+	if err := applyPolicyInput(&p, in); err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).Create(&p).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.ErrConflict
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Update replaces the writable fields of a policy.
+func (s *AccessPolicyService) Update(ctx context.Context, id uuid.UUID, in AccessPolicyInput) (*models.AccessPolicy, error) {
+	p, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyPolicyInput(p, in); err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).Save(p).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.ErrConflict
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// Delete soft-deletes a policy.
+func (s *AccessPolicyService) Delete(ctx context.Context, id uuid.UUID) error {
+	p, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Delete(p).Error
+}
+
+func applyPolicyInput(p *models.AccessPolicy, in AccessPolicyInput) error {
+	if strings.TrimSpace(in.Name) == "" {
+		return errors.Invalid("name", errors.MsgRequired)
+	}
+	p.Name = in.Name
+	p.Description = in.Description
+	p.Action = in.Action
+	p.Effect = in.Effect
+	p.Priority = in.Priority
+	p.Conditions = in.Conditions
+	if in.Enabled != nil {
+		p.Enabled = *in.Enabled
+	}
+	if err := abac.Validate(toRule(*p)); err != nil {
+		return errors.Invalid("rule", errors.MsgInvalidReason, "reason", err.Error())
+	}
+	return nil
+}
+
+// RulesFor returns the enabled rules whose action pattern could match action.
+func (s *AccessPolicyService) RulesFor(ctx context.Context, action string) ([]abac.Rule, error) {
+	patterns := []string{action, "*"}
+	if i := strings.Index(action, ":"); i > 0 {
+		patterns = append(patterns, action[:i]+":*")
+	}
+	var list []models.AccessPolicy
+	if err := s.db.WithContext(ctx).Where("enabled = ? AND action IN ?", true, patterns).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	rules := make([]abac.Rule, len(list))
+	for i := range list {
+		rules[i] = toRule(list[i])
+	}
+	return rules, nil
+}
+
+func toRule(p models.AccessPolicy) abac.Rule {
+	conv := func(in []models.PolicyCondition) []abac.Condition {
+		out := make([]abac.Condition, len(in))
+		for i, c := range in {
+			out[i] = abac.Condition{Attr: c.Attr, Op: c.Op, Value: c.Value, ValueAttr: c.ValueAttr}
+		}
+		return out
+	}
+	return abac.Rule{
+		ID:       p.ID.String(),
+		Name:     p.Name,
+		Action:   p.Action,
+		Effect:   abac.Effect(p.Effect),
+		Priority: p.Priority,
+		All:      conv(p.Conditions.All),
+		Any:      conv(p.Conditions.Any),
+	}
+}
+
+// SubjectAttributes returns the attributes of a user: id, email, username, city and role slugs.
+func (s *AccessPolicyService) SubjectAttributes(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	roles, err := NewAuthService(s.db).UserRoleSlugs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roleList := make([]interface{}, len(roles))
+	for i, r := range roles {
+		roleList[i] = r
+	}
+	return map[string]interface{}{
+		"id":       user.ID.String(),
+		"email":    user.Email,
+		"username": user.Username,
+		"city":     user.City,
+		"roles":    roleList,
+	}, nil
+}
+
+// ResourceAttributes returns the attributes of a stored resource ("place" or "plan").
+// Unknown types, or a nil id, yield just {"type": resourceType}. Places have no city column: a
+// city is only available as details.city when the place type's form stores one. Plans expose no
+// invitation or membership data, and no plan route enforces policies yet (dry runs only).
+func (s *AccessPolicyService) ResourceAttributes(ctx context.Context, resourceType string, id uuid.UUID) (map[string]interface{}, error) {
+	attrs := map[string]interface{}{"type": resourceType}
+	if id == uuid.Nil {
+		return attrs, nil
+	}
+	attrs["id"] = id.String()
+	switch resourceType {
+	case "place":
+		var p models.Place
+		// Unscoped so restoring a deleted place still sees its attributes.
+		if err := s.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&p).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return attrs, nil
+			}
+			return nil, err
+		}
+		attrs["name"] = p.Name
+		attrs["address"] = p.Address
+		attrs["place_type_id"] = p.PlaceTypeID.String()
+		attrs["is_verified"] = p.IsVerified
+		attrs["details"] = map[string]interface{}(p.Details)
+		if p.OwnerID != nil {
+			attrs["owner_id"] = p.OwnerID.String()
+		}
+	case "plan":
+		var p models.Plan
+		if err := s.db.WithContext(ctx).Where("id = ?", id).First(&p).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return attrs, nil
+			}
+			return nil, err
+		}
+		attrs["creator_id"] = p.CreatorID.String()
+		attrs["visibility"] = string(p.Visibility)
+		attrs["is_template"] = p.IsTemplate
+	}
+	return attrs, nil
+}
+
+// Evaluate runs the stored rules for req.Action against req.
+func (s *AccessPolicyService) Evaluate(ctx context.Context, req abac.Request) (abac.Decision, error) {
+	rules, err := s.RulesFor(ctx, req.Action)
+	if err != nil {
+		return abac.Decision{}, err
+	}
+	return abac.Evaluate(rules, req), nil
+}
+
+// DryRunInput describes a hypothetical request. Subject / Resource attributes are loaded from
+// SubjectID / ResourceType+ResourceID when given, then overridden by the literal maps.
+type DryRunInput struct {
+	Action       string                 `json:"action"`
+	SubjectID    *uuid.UUID             `json:"subject_id"`
+	Subject      map[string]interface{} `json:"subject"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   *uuid.UUID             `json:"resource_id"`
+	Resource     map[string]interface{} `json:"resource"`
+	Context      map[string]interface{} `json:"context"`
+}
+
+// DryRun evaluates a hypothetical request and returns the request as evaluated with the decision.
+func (s *AccessPolicyService) DryRun(ctx context.Context, in DryRunInput) (abac.Request, abac.Decision, error) {
+	if in.Action == "" {
+		return abac.Request{}, abac.Decision{}, errors.Invalid("action", errors.MsgRequired)
+	}
+	req := abac.Request{
+		Action:   in.Action,
+		Subject:  map[string]interface{}{},
+		Resource: map[string]interface{}{},
+		Context:  in.Context,
+	}
+	if req.Context == nil {
+		req.Context = map[string]interface{}{}
+	}
+	if in.SubjectID != nil {
+		attrs, err := s.SubjectAttributes(ctx, *in.SubjectID)
+		if err != nil {
+			return abac.Request{}, abac.Decision{}, err
+		}
+		req.Subject = attrs
+	}
+	for k, v := range in.Subject {
+		req.Subject[k] = v
+	}
+	if in.ResourceType != "" {
+		id := uuid.Nil
+		if in.ResourceID != nil {
+			id = *in.ResourceID
+		}
+		attrs, err := s.ResourceAttributes(ctx, in.ResourceType, id)
+		if err != nil {
+			return abac.Request{}, abac.Decision{}, err
+		}
+		req.Resource = attrs
+	}
+	for k, v := range in.Resource {
+		req.Resource[k] = v
+	}
+	d, err := s.Evaluate(ctx, req)
+	return req, d, err
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"strings"
+	"time"
 
 	"ducksrow/backend/errors"
 	"ducksrow/backend/models"
@@ -55,7 +56,7 @@ func (s *AuthService) RegisterUser(ctx context.Context, username, email, passwor
 }
 
 // AuthenticateUser verifies credentials and returns the user and their role slugs.
-// Returns errors.ErrUserNotFound if email not found or password invalid.
+// Returns errors.ErrUnauthorized if email not found or password invalid, errors.ErrAccountSuspended if suspended.
 func (s *AuthService) AuthenticateUser(ctx context.Context, email, password string) (*models.User, []string, error) {
 	var user models.User
 	if err := s.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
@@ -67,6 +68,9 @@ func (s *AuthService) AuthenticateUser(ctx context.Context, email, password stri
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return nil, nil, errors.ErrUnauthorized
 	}
+	if user.IsSuspended(time.Now()) {
+		return nil, nil, errors.ErrAccountSuspended
+	}
 	slugs, err := s.UserRoleSlugs(ctx, user.ID)
 	if err != nil {
 		return &user, nil, err
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// EmailSender delivers email verification tokens.
+type EmailSender interface {
+	SendEmailVerification(ctx context.Context, to, token string) error
+}
+
+// defaultEmailSender is used by services built after it is set; see SetEmailSender.
+var defaultEmailSender = emailSenderFromEnv()
+
+// SetEmailSender replaces the sender used by services created afterwards (call it before
+// routes.Setup to plug in a mail provider).
+func SetEmailSender(s EmailSender) {
+	defaultEmailSender = s
+}
+
+// emailSenderFromEnv returns an SMTP sender when SMTP_ADDR (host:port) is set, configured by
+// SMTP_FROM, SMTP_USERNAME, SMTP_PASSWORD and EMAIL_VERIFICATION_URL (link the token is appended
+// to as ?token=). Otherwise mail is not sent and only the recipient is logged.
+func emailSenderFromEnv() EmailSender {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return logEmailSender{}
+	}
+	s := &smtpEmailSender{
+		addr:      addr,
+		from:      os.Getenv("SMTP_FROM"),
+		verifyURL: os.Getenv("EMAIL_VERIFICATION_URL"),
+	}
+	if user := os.Getenv("SMTP_USERNAME"); user != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return s
+}
+
+// logEmailSender drops the mail and logs the recipient only; the token is a secret and never logged.
+type logEmailSender struct{}
+
+func (logEmailSender) SendEmailVerification(_ context.Context, to, _ string) error {
+	log.Printf("email verification for %s not sent: SMTP_ADDR is not set", to)
+	return nil
+}
+
+// smtpEmailSender sends plain-text mail through an SMTP relay.
+type smtpEmailSender struct {
+	addr      string
+	from      string
+	auth      smtp.Auth
+	verifyURL string
+}
+
+func (s *smtpEmailSender) SendEmailVerification(_ context.Context, to, token string) error {
+	body := "Use this code to confirm your new email address: " + token + "\r\n"
+	if s.verifyURL != "" {
+		sep := "?"
+		if strings.Contains(s.verifyURL, "?") {
+			sep = "&"
+		}
+		body += "\r\nOr open " + s.verifyURL + sep + "token=" + url.QueryEscape(token) + "\r\n"
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: Confirm your email address\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n%s", s.from, to, body)
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("send email verification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+)
+
+// Form field types supported in PlaceType.FormSchema.
+const (
+	FormFieldText        = "text"
+	FormFieldNumber      = "number"
+	FormFieldBoolean     = "boolean"
+	FormFieldSelect      = "select"
+	FormFieldMultiselect = "multiselect"
+)
+
+// FormField is one entry of PlaceType.FormSchema:
+//
+//	{"fields": [{"key": "wifi", "label": "Wi-Fi", "type": "boolean", "required": true}, ...]}
+//
+// Type defaults to text; Options lists the allowed values of select / multiselect fields.
+type FormField struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// FormFields returns the fields declared in the schema, in order. A schema without a "fields"
+// list declares nothing (any details are accepted).
+func FormFields(schema models.FormSchemaJSON) []FormField {
+	raw, ok := schema["fields"]
+	if !ok {
+		return nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var fields []FormField
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+	out := fields[:0]
+	for _, f := range fields {
+		if f.Key == "" {
+			continue
+		}
+		if f.Type == "" {
+			f.Type = FormFieldText
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// coerceFormValue converts v (a JSON value, or a string read from CSV) to the field's type.
+// Multiselect values given as a string are split on "|".
+func coerceFormValue(f FormField, v interface{}) (interface{}, error) {
+	s, isString := v.(string)
+	switch f.Type {
+	case FormFieldNumber:
+		if isString {
+			n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return nil, errors.Invalid("details."+f.Key, errors.MsgNumber)
+			}
+			return n, nil
+		}
+		if _, ok := v.(float64); !ok {
+			return nil, errors.Invalid("details."+f.Key, errors.MsgNumber)
+		}
+	case FormFieldBoolean:
+		if isString {
+			b, err := strconv.ParseBool(strings.TrimSpace(s))
+			if err != nil {
+				return nil, errors.Invalid("details."+f.Key, errors.MsgOneOf, "values", "true, false")
+			}
+			return b, nil
+		}
+		if _, ok := v.(bool); !ok {
+			return nil, errors.Invalid("details."+f.Key, errors.MsgOneOf, "values", "true, false")
+		}
+	case FormFieldSelect:
+		if !isString || !optionAllowed(f, s) {
+			return nil, errors.Invalid("details."+f.Key, errors.MsgOneOf, "values", strings.Join(f.Options, ", "))
+		}
+	case FormFieldMultiselect:
+		var list []string
+		switch x := v.(type) {
+		case string:
+			for _, item := range strings.Split(x, "|") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+		case []interface{}:
+			for _, item := range x {
+				str, ok := item.(string)
+				if !ok {
+					return nil, errors.Invalid("details."+f.Key, errors.MsgStringList)
+				}
+				list = append(list, str)
+			}
+		default:
+			return nil, errors.Invalid("details."+f.Key, errors.MsgStringList)
+		}
+		out := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			if !optionAllowed(f, item) {
+				return nil, errors.Invalid("details."+f.Key, errors.MsgOneOf, "values", strings.Join(f.Options, ", "))
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	default:
+		if !isString {
+			return fmt.Sprint(v), nil
+		}
+	}
+	return v, nil
+}
+
+func optionAllowed(f FormField, v string) bool {
+	if len(f.Options) == 0 {
+		return true
+	}
+	return stringIn(f.Options, v)
+}
+
+// validateDetails checks details against the place type's form fields: unknown keys, missing
+// required fields and values of the wrong type are rejected. Values are converted in place.
+func validateDetails(fields []FormField, details map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	byKey := make(map[string]FormField, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+	for k, v := range details {
+		f, ok := byKey[k]
+		if !ok {
+			return errors.Invalid("details."+k, errors.MsgNotInSchema)
+		}
+		cv, err := coerceFormValue(f, v)
+		if err != nil {
+			return err
+		}
+		details[k] = cv
+	}
+	for _, f := range fields {
+		if _, ok := details[f.Key]; f.Required && !ok {
+			return errors.Invalid("details."+f.Key, errors.MsgRequired)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultImpersonationTTL is the lifetime of an impersonation token when IMPERSONATION_TTL is unset.
+const defaultImpersonationTTL = 15 * time.Minute
+
+// ImpersonationService validates and records admin impersonation sessions.
+type ImpersonationService struct {
+	db *gorm.DB
+}
+
+// NewImpersonationService returns an ImpersonationService.
+func NewImpersonationService(db *gorm.DB) *ImpersonationService {
+	return &ImpersonationService{db: db}
+}
+
+// ImpersonationTTL returns the configured token lifetime (IMPERSONATION_TTL, Go duration; default 15m).
+func ImpersonationTTL() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("IMPERSONATION_TTL")); err == nil && d > 0 {
+		return d
+	}
+	return defaultImpersonationTTL
+}
+
+// Start checks that adminID may impersonate targetID and writes an impersonate audit entry.
+// Admins cannot impersonate themselves or other admins. Returns the target user.
+func (s *ImpersonationService) Start(ctx context.Context, adminID, targetID uuid.UUID) (*models.User, error) {
+	if adminID == targetID {
+		return nil, errors.Msg(errors.ErrValidation, errors.MsgSelfTarget)
+	}
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", targetID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	isAdmin, err := NewPermissionService(s.db).IsAdmin(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		return nil, errors.Msg(errors.ErrForbidden, errors.MsgImpersonateAdmin)
+	}
+	audit := models.RoleAuditLog{
+		ActorID:      adminID,
+		Action:       models.AuditActionImpersonate,
+		TargetUserID: &targetID,
+		Detail:       "ttl=" + ImpersonationTTL().String(),
+	}
+	if err := s.db.WithContext(ctx).Create(&audit).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CheckImpersonator re-validates the admin behind an impersonation token on each request: the
+// account must still exist, not be suspended and still hold the admin role.
+func (s *ImpersonationService) CheckImpersonator(ctx context.Context, impersonatorID uuid.UUID) error {
+	var admin models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", impersonatorID).First(&admin).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.Msg(errors.ErrNotAuthenticated, errors.MsgInvalidToken)
+		}
+		return err
+	}
+	if admin.IsSuspended(time.Now()) {
+		return errors.Msg(errors.ErrNotAuthenticated, errors.MsgInvalidToken)
+	}
+	isAdmin, err := NewPermissionService(s.db).IsAdmin(ctx, impersonatorID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return errors.Msg(errors.ErrNotAuthenticated, errors.MsgInvalidToken)
+	}
+	return nil
+}
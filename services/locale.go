@@ -0,0 +1,81 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// supportedLocales and defaultLocale come from SUPPORTED_LOCALES (comma-separated, default
+// "ar,en,fr") and DEFAULT_LOCALE (default "en").
+var supportedLocales, defaultLocale = localesFromEnv()
+
+func localesFromEnv() ([]string, string) {
+	supported := []string{"ar", "en", "fr"}
+	if raw := os.Getenv("SUPPORTED_LOCALES"); raw != "" {
+		supported = nil
+		for _, l := range strings.Split(raw, ",") {
+			if l = normalizeLocale(l); l != "" {
+				supported = append(supported, l)
+			}
+		}
+	}
+	def := normalizeLocale(os.Getenv("DEFAULT_LOCALE"))
+	if def == "" {
+		def = "en"
+	}
+	if !stringIn(supported, def) {
+		supported = append(supported, def)
+	}
+	return supported, def
+}
+
+// IsSupportedLocale reports whether translations may be stored in the locale.
+func IsSupportedLocale(locale string) bool {
+	return stringIn(supportedLocales, locale)
+}
+
+// NegotiateLocales turns an Accept-Language header into the fallback chain used to pick
+// translations: the supported locales the client accepts, by preference, each followed by its
+// base language ("fr-ca" → "fr"), and finally the default locale. Never empty.
+func NegotiateLocales(acceptLanguage string) []string {
+	type tag struct {
+		locale string
+		q      float64
+		pos    int
+	}
+	var tags []tag
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if l := normalizeLocale(name); l != "" && l != "*" && q > 0 {
+			tags = append(tags, tag{l, q, i})
+		}
+	}
+	sort.SliceStable(tags, func(a, b int) bool { return tags[a].q > tags[b].q })
+	var chain []string
+	add := func(l string) {
+		if IsSupportedLocale(l) && !stringIn(chain, l) {
+			chain = append(chain, l)
+		}
+	}
+	for _, t := range tags {
+		add(t.locale)
+		if base, _, ok := strings.Cut(t.locale, "-"); ok {
+			add(base)
+		}
+	}
+	add(defaultLocale)
+	return chain
+}
+
+// normalizeLocale lower-cases a language tag and uses "-" as separator ("pt_BR" → "pt-br").
+func normalizeLocale(l string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(l), "_", "-"))
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"ducksrow/backend/errors"
+)
+
+// bannedWords holds the normalized words and phrases from MODERATION_BANNED_WORDS (comma-separated).
+var bannedWords = bannedWordsFromEnv()
+
+func bannedWordsFromEnv() []string {
+	var words []string
+	for _, w := range strings.Split(os.Getenv("MODERATION_BANNED_WORDS"), ",") {
+		if w = normalizeWords(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// normalizeWords lowercases s and collapses everything but letters and digits to single spaces.
+func normalizeWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// containsBannedWord reports whether text contains a banned word or phrase as whole words,
+// ignoring case and punctuation.
+func containsBannedWord(text string) bool {
+	if len(bannedWords) == 0 {
+		return false
+	}
+	padded := " " + normalizeWords(text) + " "
+	for _, w := range bannedWords {
+		if strings.Contains(padded, " "+w+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBannedWords rejects submitted text containing a banned word; fields are name, value pairs.
+func checkBannedWords(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if containsBannedWord(fields[i+1]) {
+			return errors.Invalid(fields[i], errors.MsgBannedWord)
+		}
+	}
+	return nil
+}
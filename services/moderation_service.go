@@ -0,0 +1,463 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxReportReason is the longest report reason accepted, in characters.
+const maxReportReason = 1000
+
+// ModerationService handles content reports, the moderation queue and moderation actions.
+type ModerationService struct {
+	db       *gorm.DB
+	autoHide int
+}
+
+// NewModerationService returns a ModerationService. MODERATION_AUTO_HIDE_REPORTS (default 3) is the
+// number of open reports from different users after which content is hidden automatically; 0 disables it.
+func NewModerationService(db *gorm.DB) *ModerationService {
+	autoHide := 3
+	if v, err := strconv.Atoi(os.Getenv("MODERATION_AUTO_HIDE_REPORTS")); err == nil && v >= 0 {
+		autoHide = v
+	}
+	return &ModerationService{db: db, autoHide: autoHide}
+}
+
+// ReportInput is the body of POST /api/reports.
+type ReportInput struct {
+	TargetType string    `json:"target_type"` // review | place | plan
+	TargetID   uuid.UUID `json:"target_id"`
+	Reason     string    `json:"reason"`
+}
+
+// ModerationActionInput is the body of POST /api/moderation/targets/:type/:id/actions.
+type ModerationActionInput struct {
+	Action string     `json:"action"` // hide | unhide | delete | warn | suspend | dismiss
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until"` // suspend only; omit to suspend indefinitely
+}
+
+// QueueItem is one reported target in the moderation queue.
+type QueueItem struct {
+	TargetType      string     `json:"target_type"`
+	TargetID        uuid.UUID  `json:"target_id"`
+	AuthorID        *uuid.UUID `json:"author_id,omitempty"`
+	Hidden          bool       `json:"hidden"`
+	Excerpt         string     `json:"excerpt"`
+	OpenReports     int        `json:"open_reports"`
+	FirstReportedAt time.Time  `json:"first_reported_at"`
+	LastReportedAt  time.Time  `json:"last_reported_at"`
+}
+
+// moderationTarget is the reported content with its author (nil for a place without owner or creator).
+type moderationTarget struct {
+	Type     string
+	ID       uuid.UUID
+	AuthorID *uuid.UUID
+	HiddenAt *time.Time
+	Excerpt  string
+}
+
+// Report files the user's report of a review, place or public plan. Once the target has
+// MODERATION_AUTO_HIDE_REPORTS open reports from different users it is hidden until a moderator acts.
+func (s *ModerationService) Report(ctx context.Context, reporterID uuid.UUID, in ReportInput) (*models.ContentReport, error) {
+	reason := strings.TrimSpace(in.Reason)
+	if reason == "" {
+		return nil, errors.Invalid("reason", errors.MsgRequired)
+	}
+	if len([]rune(reason)) > maxReportReason {
+		return nil, errors.Invalid("reason", errors.MsgMaxLength, "max", strconv.Itoa(maxReportReason))
+	}
+	report := models.ContentReport{
+		TargetType: in.TargetType,
+		TargetID:   in.TargetID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Status:     models.ReportOpen,
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Concurrent reports on the target wait here, so reporters are counted and the target
+		// hidden (with its audit row) once.
+		if err := lockModerationTarget(tx, in.TargetType, in.TargetID); err != nil {
+			return err
+		}
+		target, err := loadModerationTarget(tx, in.TargetType, in.TargetID, true)
+		if err != nil {
+			return err
+		}
+		if target.AuthorID != nil && *target.AuthorID == reporterID {
+			return errors.Msg(errors.ErrValidation, errors.MsgOwnContent)
+		}
+		if err := tx.Create(&report).Error; err != nil {
+			if isUniqueViolation(err) {
+				return errors.Msg(errors.ErrConflict, errors.MsgAlreadyReported)
+			}
+			return err
+		}
+		if s.autoHide == 0 || target.HiddenAt != nil {
+			return nil
+		}
+		var reporters int64
+		if err := tx.Model(&models.ContentReport{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", target.Type, target.ID, models.ReportOpen).
+			Distinct("reporter_id").Count(&reporters).Error; err != nil {
+			return err
+		}
+		if reporters < int64(s.autoHide) {
+			return nil
+		}
+		if err := setModerationHidden(tx, target, true); err != nil {
+			return err
+		}
+		return tx.Create(&models.ModerationAction{
+			TargetType:   target.Type,
+			TargetID:     target.ID,
+			Action:       models.ModerationHide,
+			TargetUserID: target.AuthorID,
+			Reason:       fmt.Sprintf("hidden automatically after %d reports", reporters),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Queue returns the targets with open reports, most reported first.
+func (s *ModerationService) Queue(ctx context.Context, targetType string, page, limit int) ([]QueueItem, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if targetType != "" {
+		if err := validateModerationTargetType(targetType); err != nil {
+			return nil, 0, err
+		}
+	}
+	db := s.db.WithContext(ctx)
+	open := func() *gorm.DB {
+		q := db.Model(&models.ContentReport{}).Where("status = ?", models.ReportOpen)
+		if targetType != "" {
+			q = q.Where("target_type = ?", targetType)
+		}
+		return q
+	}
+	var total int64
+	if err := db.Table("(?) AS targets", open().Select("target_type, target_id").Group("target_type, target_id")).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var items []QueueItem
+	if err := open().Select("target_type, target_id, COUNT(*) AS open_reports, " +
+		"MIN(created_at) AS first_reported_at, MAX(created_at) AS last_reported_at").
+		Group("target_type, target_id").Order("open_reports DESC, first_reported_at ASC").
+		Offset((page - 1) * limit).Limit(limit).Scan(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	for i := range items {
+		target, err := loadModerationTarget(db, items[i].TargetType, items[i].TargetID, false)
+		if err != nil {
+			// Deleted outside moderation; it still shows so the reports can be dismissed.
+			continue
+		}
+		items[i].AuthorID, items[i].Hidden, items[i].Excerpt = target.AuthorID, target.HiddenAt != nil, target.Excerpt
+	}
+	return items, total, nil
+}
+
+// Reports lists reports, newest first, optionally by status and target.
+func (s *ModerationService) Reports(ctx context.Context, status, targetType string, targetID *uuid.UUID, page, limit int) ([]models.ContentReport, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.ContentReport{})
+	switch status {
+	case "":
+	case models.ReportOpen, models.ReportActioned, models.ReportDismissed:
+		q = q.Where("status = ?", status)
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "open, actioned, dismissed")
+	}
+	if targetType != "" {
+		if err := validateModerationTargetType(targetType); err != nil {
+			return nil, 0, err
+		}
+		q = q.Where("target_type = ?", targetType)
+	}
+	if targetID != nil {
+		q = q.Where("target_id = ?", *targetID)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.ContentReport
+	err := q.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// Act applies a moderation action to the target, closes its open reports (dismissed for
+// dismiss, actioned otherwise) and records the action, all in one transaction. suspend goes
+// through UserAdminService.Suspend, so it is also in the role audit log.
+func (s *ModerationService) Act(ctx context.Context, actorID uuid.UUID, targetType string, targetID uuid.UUID, in ModerationActionInput) (*models.ModerationAction, error) {
+	reason := strings.TrimSpace(in.Reason)
+	switch in.Action {
+	case models.ModerationHide, models.ModerationUnhide, models.ModerationDelete, models.ModerationDismiss:
+	case models.ModerationWarn, models.ModerationSuspend:
+		if reason == "" {
+			return nil, errors.Invalid("reason", errors.MsgRequired)
+		}
+	default:
+		return nil, errors.Invalid("action", errors.MsgOneOf, "values", "hide, unhide, delete, warn, suspend, dismiss")
+	}
+	var action models.ModerationAction
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockModerationTarget(tx, targetType, targetID); err != nil {
+			return err
+		}
+		target, err := loadModerationTarget(tx, targetType, targetID, false)
+		if err != nil {
+			// Reports on content deleted outside moderation can still be dismissed.
+			if status, _ := errors.HTTPStatusAndCode(err); status != 404 || in.Action != models.ModerationDismiss {
+				return err
+			}
+			target = &moderationTarget{Type: targetType, ID: targetID}
+		}
+		switch in.Action {
+		case models.ModerationHide, models.ModerationUnhide:
+			if err := setModerationHidden(tx, target, in.Action == models.ModerationHide); err != nil {
+				return err
+			}
+		case models.ModerationDelete:
+			if err := deleteModerationTarget(ctx, tx, actorID, target); err != nil {
+				return err
+			}
+		case models.ModerationWarn, models.ModerationSuspend:
+			if target.AuthorID == nil {
+				return errors.Invalid("action", errors.MsgNoAuthor, "value", in.Action)
+			}
+			if in.Action == models.ModerationSuspend {
+				if err := NewUserAdminService(tx).Suspend(ctx, actorID, *target.AuthorID, reason, in.Until); err != nil {
+					return err
+				}
+			}
+		}
+		status := models.ReportActioned
+		if in.Action == models.ModerationDismiss {
+			status = models.ReportDismissed
+		}
+		if err := tx.Model(&models.ContentReport{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", target.Type, target.ID, models.ReportOpen).
+			Updates(map[string]interface{}{"status": status, "resolved_by": actorID, "resolved_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		action = models.ModerationAction{
+			TargetType:   target.Type,
+			TargetID:     target.ID,
+			Action:       in.Action,
+			ActorID:      &actorID,
+			TargetUserID: target.AuthorID,
+			Reason:       reason,
+		}
+		return tx.Create(&action).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// Actions returns the moderation audit trail, newest first, optionally for one target or one author.
+func (s *ModerationService) Actions(ctx context.Context, targetType string, targetID, userID *uuid.UUID, page, limit int) ([]models.ModerationAction, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.ModerationAction{})
+	if targetType != "" {
+		if err := validateModerationTargetType(targetType); err != nil {
+			return nil, 0, err
+		}
+		q = q.Where("target_type = ?", targetType)
+	}
+	if targetID != nil {
+		q = q.Where("target_id = ?", *targetID)
+	}
+	if userID != nil {
+		q = q.Where("target_user_id = ?", *userID)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.ModerationAction
+	err := q.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+func validateModerationTargetType(t string) error {
+	switch t {
+	case models.ModerationTargetReview, models.ModerationTargetPlace, models.ModerationTargetPlan:
+		return nil
+	}
+	return errors.Invalid("target_type", errors.MsgOneOf, "values", "review, place, plan")
+}
+
+// lockModerationTarget locks the target's row until the transaction ends; a missing target is
+// left for loadModerationTarget to report.
+func lockModerationTarget(tx *gorm.DB, targetType string, id uuid.UUID) error {
+	var model interface{}
+	switch targetType {
+	case models.ModerationTargetReview:
+		model = &models.PlaceReview{}
+	case models.ModerationTargetPlace:
+		model = &models.Place{}
+	case models.ModerationTargetPlan:
+		model = &models.Plan{}
+	default:
+		return validateModerationTargetType(targetType)
+	}
+	var ids []uuid.UUID
+	return tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Pluck("id", &ids).Error
+}
+
+// loadModerationTarget loads the content to report (reporting: public plans only, hidden content
+// included so reports keep counting) or to moderate.
+func loadModerationTarget(db *gorm.DB, targetType string, id uuid.UUID, reporting bool) (*moderationTarget, error) {
+	if err := validateModerationTargetType(targetType); err != nil {
+		return nil, err
+	}
+	t := moderationTarget{Type: targetType, ID: id}
+	switch targetType {
+	case models.ModerationTargetReview:
+		var r models.PlaceReview
+		if err := db.Where("id = ?", id).First(&r).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.ErrReviewNotFound
+			}
+			return nil, err
+		}
+		t.AuthorID, t.HiddenAt, t.Excerpt = &r.AuthorID, r.HiddenAt, r.Text
+	case models.ModerationTargetPlace:
+		var p models.Place
+		if err := db.Where("id = ?", id).First(&p).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.ErrPlaceNotFound
+			}
+			return nil, err
+		}
+		t.AuthorID, t.HiddenAt, t.Excerpt = p.OwnerID, p.HiddenAt, p.Name
+		if t.AuthorID == nil {
+			// No owner: the author is whoever created it.
+			var h models.PlaceHistory
+			err := db.Where("place_id = ? AND action = ?", id, models.PlaceHistoryCreate).Order("created_at").First(&h).Error
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return nil, err
+			}
+			t.AuthorID = h.ActorID
+		}
+	case models.ModerationTargetPlan:
+		var p models.Plan
+		q := db.Where("id = ?", id)
+		if reporting {
+			q = q.Where("visibility = ?", models.VisibilityPublic)
+		}
+		if err := q.First(&p).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.ErrPlanNotFound
+			}
+			return nil, err
+		}
+		t.AuthorID, t.HiddenAt, t.Excerpt = &p.CreatorID, p.HiddenAt, p.Title
+	}
+	if len([]rune(t.Excerpt)) > 200 {
+		t.Excerpt = string([]rune(t.Excerpt)[:200]) + "…"
+	}
+	return &t, nil
+}
+
+// setModerationHidden hides or shows the target, keeping the place rating and map tiles in step.
+func setModerationHidden(tx *gorm.DB, t *moderationTarget, hidden bool) error {
+	var hiddenAt *time.Time
+	if hidden {
+		now := time.Now()
+		hiddenAt = &now
+	}
+	switch t.Type {
+	case models.ModerationTargetReview:
+		var r models.PlaceReview
+		if err := tx.Where("id = ?", t.ID).First(&r).Error; err != nil {
+			return err
+		}
+		if _, err := lockReviewedPlace(tx, r.PlaceID); err != nil && err != errors.ErrPlaceNotFound {
+			return err
+		}
+		if err := tx.Model(&r).UpdateColumn("hidden_at", hiddenAt).Error; err != nil {
+			return err
+		}
+		return updatePlaceRating(tx, r.PlaceID)
+	case models.ModerationTargetPlace:
+		var p models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", t.ID).First(&p).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&p).UpdateColumn("hidden_at", hiddenAt).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &p)
+		return nil
+	default:
+		return tx.Model(&models.Plan{}).Where("id = ?", t.ID).UpdateColumn("hidden_at", hiddenAt).Error
+	}
+}
+
+// deleteModerationTarget removes the target: reviews for good (updating the place rating), places
+// like PlaceService.Delete (restorable from revisions), plans by soft delete.
+func deleteModerationTarget(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, t *moderationTarget) error {
+	switch t.Type {
+	case models.ModerationTargetReview:
+		var r models.PlaceReview
+		if err := tx.Where("id = ?", t.ID).First(&r).Error; err != nil {
+			return err
+		}
+		if _, err := lockReviewedPlace(tx, r.PlaceID); err != nil && err != errors.ErrPlaceNotFound {
+			return err
+		}
+		if err := tx.Delete(&r).Error; err != nil {
+			return err
+		}
+		return updatePlaceRating(tx, r.PlaceID)
+	case models.ModerationTargetPlace:
+		return NewPlaceService(tx).Delete(ctx, actorID, t.ID)
+	default:
+		return tx.Where("id = ?", t.ID).Delete(&models.Plan{}).Error
+	}
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // place timezones must resolve even on hosts without zoneinfo
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+)
+
+const minutesPerDay = 24 * 60
+
+var (
+	osmDayNames   = []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+	osmMonthNames = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	osmDayRe      = `(?:Mo|Tu|We|Th|Fr|Sa|Su)`
+	osmDaysRe     = regexp.MustCompile(`^(` + osmDayRe + `(?:-` + osmDayRe + `)?(?:,` + osmDayRe + `(?:-` + osmDayRe + `)?)*)(?:\s+(.*))?$`)
+	osmDateRe     = regexp.MustCompile(`^(\d{4}) (Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) (\d{1,2})(?:\s+(.*))?$`)
+	osmSpanRe     = regexp.MustCompile(`^(\d{1,2}:\d{2})-(\d{1,2}:\d{2})$`)
+)
+
+// ParseOSMOpeningHours parses OpenStreetMap opening_hours syntax into a weekly schedule and date
+// exceptions. Supported: "24/7"; rules separated by ";" with optional weekday selectors
+// ("Mo-Fr", "Mo,We,Sa-Su"), comma-separated time spans ("08:00-12:00,13:00-18:00", overnight as
+// "22:00-02:00" or "22:00-26:00") or "off" / "closed"; and dated exceptions ("2024 Dec 25 off",
+// "2024 Dec 24 10:00-14:00"). As in OSM, a later rule replaces earlier ones for the days it names.
+// Other selectors (PH, SH, week and month ranges, comments) are rejected.
+func ParseOSMOpeningHours(s string) (models.OpeningWeekJSON, models.OpeningExceptionsJSON, error) {
+	s = strings.TrimSpace(s)
+	weekly := models.OpeningWeekJSON{}
+	exceptions := models.OpeningExceptionsJSON{}
+	if s == "24/7" {
+		for _, d := range models.OpeningWeekdays {
+			weekly[d] = []models.OpeningInterval{{Opens: "00:00", Closes: "24:00"}}
+		}
+		return weekly, exceptions, nil
+	}
+	byDate := map[string]int{}
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if m := osmDateRe.FindStringSubmatch(rule); m != nil {
+			day, _ := strconv.Atoi(m[3])
+			month := time.Month(indexOf(osmMonthNames, m[2]) + 1)
+			year, _ := strconv.Atoi(m[1])
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			if date.Day() != day {
+				return nil, nil, errors.Invalid("osm", errors.MsgOSMRule, "rule", rule)
+			}
+			intervals, err := parseOSMSpans(m[4], rule)
+			if err != nil {
+				return nil, nil, err
+			}
+			key := date.Format("2006-01-02")
+			if i, ok := byDate[key]; ok {
+				exceptions[i].Intervals = intervals
+			} else {
+				byDate[key] = len(exceptions)
+				exceptions = append(exceptions, models.OpeningException{Date: key, Intervals: intervals})
+			}
+			continue
+		}
+		days := models.OpeningWeekdays
+		spans := rule
+		if m := osmDaysRe.FindStringSubmatch(rule); m != nil {
+			days = expandOSMDays(m[1])
+			spans = m[2]
+			if strings.TrimSpace(spans) == "" {
+				spans = "00:00-24:00"
+			}
+		}
+		intervals, err := parseOSMSpans(spans, rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, d := range days {
+			weekly[d] = intervals
+		}
+	}
+	for d, intervals := range weekly {
+		if len(intervals) == 0 {
+			delete(weekly, d)
+		}
+	}
+	return weekly, exceptions, nil
+}
+
+// parseOSMSpans parses "off" / "closed" (no intervals) or comma-separated HH:MM-HH:MM spans.
+func parseOSMSpans(s, rule string) ([]models.OpeningInterval, error) {
+	s = strings.TrimSpace(s)
+	if s == "off" || s == "closed" {
+		return []models.OpeningInterval{}, nil
+	}
+	if s == "" {
+		return nil, errors.Invalid("osm", errors.MsgOSMRule, "rule", rule)
+	}
+	var out []models.OpeningInterval
+	for _, part := range strings.Split(s, ",") {
+		m := osmSpanRe.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, errors.Invalid("osm", errors.MsgOSMRule, "rule", rule)
+		}
+		opens, err1 := parseClock(m[1], minutesPerDay-1)
+		closes, err2 := parseClock(m[2], 2*minutesPerDay)
+		if err1 != nil || err2 != nil || opens == closes {
+			return nil, errors.Invalid("osm", errors.MsgOSMSpan, "span", strings.TrimSpace(part), "rule", rule)
+		}
+		if closes > minutesPerDay {
+			closes -= minutesPerDay
+		}
+		out = append(out, models.OpeningInterval{Opens: formatClock(opens), Closes: formatClock(closes)})
+	}
+	return out, nil
+}
+
+// expandOSMDays turns "Mo-We,Sa" into weekday keys; ranges may wrap ("Fr-Mo").
+func expandOSMDays(sel string) []string {
+	var out []string
+	for _, part := range strings.Split(sel, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		i := indexOf(osmDayNames, from)
+		if !isRange {
+			out = append(out, models.OpeningWeekdays[i])
+			continue
+		}
+		j := indexOf(osmDayNames, to)
+		for k := i; ; k = (k + 1) % 7 {
+			out = append(out, models.OpeningWeekdays[k])
+			if k == j {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// FormatOSMOpeningHours serialises a schedule as OSM opening_hours, grouping consecutive days
+// with the same hours ("Mo-Fr 08:00-18:00; Sa 09:00-12:00; 2024 Dec 25 off").
+func FormatOSMOpeningHours(weekly models.OpeningWeekJSON, exceptions models.OpeningExceptionsJSON) string {
+	spans := make([]string, 7)
+	allDay := len(exceptions) == 0
+	for i, d := range models.OpeningWeekdays {
+		spans[i] = formatOSMSpans(weekly[d])
+		allDay = allDay && spans[i] == "00:00-24:00"
+	}
+	if allDay {
+		return "24/7"
+	}
+	var rules []string
+	for i := 0; i < 7; {
+		j := i
+		for j+1 < 7 && spans[j+1] == spans[i] {
+			j++
+		}
+		if spans[i] != "off" {
+			sel := osmDayNames[i]
+			if j > i {
+				sel += "-" + osmDayNames[j]
+			}
+			rules = append(rules, sel+" "+spans[i])
+		}
+		i = j + 1
+	}
+	sorted := append(models.OpeningExceptionsJSON{}, exceptions...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Date < sorted[b].Date })
+	for _, e := range sorted {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, fmt.Sprintf("%d %s %02d %s", date.Year(), osmMonthNames[date.Month()-1], date.Day(), formatOSMSpans(e.Intervals)))
+	}
+	if len(rules) == 0 {
+		return "off"
+	}
+	return strings.Join(rules, "; ")
+}
+
+func formatOSMSpans(intervals []models.OpeningInterval) string {
+	if len(intervals) == 0 {
+		return "off"
+	}
+	parts := make([]string, len(intervals))
+	for i, iv := range intervals {
+		parts[i] = iv.Opens + "-" + iv.Closes
+	}
+	return strings.Join(parts, ",")
+}
+
+// validateOpeningHours checks the timezone, weekday keys, clock values and exception dates.
+func validateOpeningHours(timezone string, weekly models.OpeningWeekJSON, exceptions models.OpeningExceptionsJSON) error {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return errors.Invalid("timezone", errors.MsgTimezone)
+	}
+	check := func(where string, intervals []models.OpeningInterval) error {
+		for _, iv := range intervals {
+			opens, err1 := parseClock(iv.Opens, minutesPerDay-1)
+			closes, err2 := parseClock(iv.Closes, minutesPerDay)
+			if err1 != nil || err2 != nil || opens == closes {
+				return errors.Invalid(where, errors.MsgInterval, "opens", iv.Opens, "closes", iv.Closes)
+			}
+		}
+		return nil
+	}
+	for d, intervals := range weekly {
+		if indexOf(models.OpeningWeekdays, d) < 0 {
+			return errors.Invalid("weekly", errors.MsgOneOf, "values", strings.Join(models.OpeningWeekdays, ", "))
+		}
+		if err := check("weekly."+d, intervals); err != nil {
+			return err
+		}
+	}
+	seen := map[string]bool{}
+	for _, e := range exceptions {
+		if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+			return errors.Invalid("exceptions.date", errors.MsgFormat, "format", "YYYY-MM-DD")
+		}
+		if seen[e.Date] {
+			return errors.Invalid("exceptions.date", errors.MsgDuplicateValue, "value", e.Date)
+		}
+		seen[e.Date] = true
+		if err := check("exceptions."+e.Date, e.Intervals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openingIntervalRows flattens a schedule into PlaceOpeningInterval rows (see the model).
+func openingIntervalRows(placeID uuid.UUID, weekly models.OpeningWeekJSON, exceptions models.OpeningExceptionsJSON) []models.PlaceOpeningInterval {
+	var rows []models.PlaceOpeningInterval
+	add := func(weekday int, date *time.Time, intervals []models.OpeningInterval) {
+		if date != nil && len(intervals) == 0 {
+			rows = append(rows, models.PlaceOpeningInterval{PlaceID: placeID, Weekday: weekday, Date: date})
+			return
+		}
+		for _, iv := range intervals {
+			opens, _ := parseClock(iv.Opens, minutesPerDay-1)
+			closes, _ := parseClock(iv.Closes, minutesPerDay)
+			if closes > opens {
+				rows = append(rows, models.PlaceOpeningInterval{PlaceID: placeID, Weekday: weekday, Date: date, Opens: opens, Closes: closes})
+				continue
+			}
+			rows = append(rows, models.PlaceOpeningInterval{PlaceID: placeID, Weekday: weekday, Date: date, Opens: opens, Closes: minutesPerDay})
+			if closes > 0 {
+				rows = append(rows, models.PlaceOpeningInterval{PlaceID: placeID, Weekday: weekday, Date: date, DayOffset: 1, Closes: closes})
+			}
+		}
+	}
+	for i, d := range models.OpeningWeekdays {
+		add(i, nil, weekly[d])
+	}
+	for _, e := range exceptions {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			continue
+		}
+		add(isoWeekday(date), &date, e.Intervals)
+	}
+	return rows
+}
+
+// isOpenAt reports whether the schedule is open at t, with the same rules as the SQL filter: an
+// exception replaces the weekly hours of its date, including the part of that day's overnight
+// spans that runs into the next day.
+func isOpenAt(h *models.PlaceOpeningHours, t time.Time) bool {
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		return false
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	for offset := 0; offset <= 1; offset++ {
+		origin := today.AddDate(0, 0, -offset)
+		intervals := h.Weekly[models.OpeningWeekdays[isoWeekday(origin)]]
+		for _, e := range h.Exceptions {
+			if e.Date == origin.Format("2006-01-02") {
+				intervals = e.Intervals
+				break
+			}
+		}
+		for _, iv := range intervals {
+			opens, _ := parseClock(iv.Opens, minutesPerDay-1)
+			closes, _ := parseClock(iv.Closes, minutesPerDay)
+			overnight := closes <= opens
+			if offset == 0 && minute >= opens && (overnight || minute < closes) {
+				return true
+			}
+			if offset == 1 && overnight && minute < closes {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// openAtCondition is the SQL condition on places for "open at ?" (one timestamptz parameter).
+const openAtCondition = `places.id IN (
+	SELECT i.place_id FROM place_opening_intervals i
+	JOIN place_opening_hours oh ON oh.place_id = i.place_id
+	CROSS JOIN LATERAL (SELECT (?::timestamptz AT TIME ZONE oh.timezone) AS lt) t
+	WHERE EXTRACT(HOUR FROM t.lt) * 60 + EXTRACT(MINUTE FROM t.lt) >= i.opens
+	  AND EXTRACT(HOUR FROM t.lt) * 60 + EXTRACT(MINUTE FROM t.lt) < i.closes
+	  AND ((i.date IS NOT NULL AND i.date + i.day_offset = t.lt::date)
+	    OR (i.date IS NULL AND i.weekday = EXTRACT(ISODOW FROM t.lt::date - i.day_offset) - 1
+	      AND NOT EXISTS (SELECT 1 FROM place_opening_intervals x
+	        WHERE x.place_id = i.place_id AND x.date = t.lt::date - i.day_offset))))`
+
+// parseClock parses "HH:MM" into minutes after midnight, up to max.
+func parseClock(s string, max int) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok || len(m) != 2 || len(h) == 0 || len(h) > 2 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	hours, err1 := strconv.Atoi(h)
+	minutes, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || minutes > 59 || hours < 0 || minutes < 0 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	v := hours*60 + minutes
+	if v > max {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return v, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// isoWeekday returns 0 for Monday … 6 for Sunday.
+func isoWeekday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func indexOf(list []string, v string) int {
+	for i, s := range list {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
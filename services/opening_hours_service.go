@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OpeningHoursService stores place opening hours and answers "open now" for a single place.
+// Listings filter on opening hours through PlaceFilter.OpenAt.
+type OpeningHoursService struct {
+	db              *gorm.DB
+	defaultTimezone string
+}
+
+// NewOpeningHoursService returns an OpeningHoursService. PLACE_DEFAULT_TIMEZONE (default UTC) is
+// used when opening hours are set without a timezone, e.g. from imported OSM data.
+func NewOpeningHoursService(db *gorm.DB) *OpeningHoursService {
+	s := &OpeningHoursService{db: db, defaultTimezone: "UTC"}
+	if tz := os.Getenv("PLACE_DEFAULT_TIMEZONE"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			s.defaultTimezone = tz
+		}
+	}
+	return s
+}
+
+// OpeningHoursInput sets opening hours either structurally (Weekly, Exceptions) or from OSM
+// opening_hours syntax (OSM); not both.
+type OpeningHoursInput struct {
+	Timezone   string                       `json:"timezone"`
+	Weekly     models.OpeningWeekJSON       `json:"weekly"`
+	Exceptions models.OpeningExceptionsJSON `json:"exceptions"`
+	OSM        string                       `json:"osm"`
+}
+
+// OpeningHoursView is a place's opening hours with whether it is open at the time of the request.
+type OpeningHoursView struct {
+	models.PlaceOpeningHours
+	OpenNow bool `json:"open_now"`
+}
+
+// Get returns the place's opening hours.
+func (s *OpeningHoursService) Get(ctx context.Context, placeID uuid.UUID) (*OpeningHoursView, error) {
+	if err := s.db.WithContext(ctx).Select("id").Where("id = ?", placeID).First(&models.Place{}).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPlaceNotFound
+		}
+		return nil, err
+	}
+	var h models.PlaceOpeningHours
+	if err := s.db.WithContext(ctx).Where("place_id = ?", placeID).First(&h).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrOpeningHoursNotSet
+		}
+		return nil, err
+	}
+	return &OpeningHoursView{PlaceOpeningHours: h, OpenNow: isOpenAt(&h, time.Now())}, nil
+}
+
+// Set replaces the place's opening hours and records the change in the place history.
+func (s *OpeningHoursService) Set(ctx context.Context, actorID *uuid.UUID, placeID uuid.UUID, in OpeningHoursInput) (*OpeningHoursView, error) {
+	h, err := s.Normalize(in)
+	if err != nil {
+		return nil, err
+	}
+	h.PlaceID = placeID
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", placeID).First(&models.Place{}).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		return setOpeningHours(tx, actorID, h)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OpeningHoursView{PlaceOpeningHours: *h, OpenNow: isOpenAt(h, time.Now())}, nil
+}
+
+// Delete removes the place's opening hours.
+func (s *OpeningHoursService) Delete(ctx context.Context, actorID *uuid.UUID, placeID uuid.UUID) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("place_id = ?", placeID).Delete(&models.PlaceOpeningHours{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errors.ErrOpeningHoursNotSet
+		}
+		if err := tx.Where("place_id = ?", placeID).Delete(&models.PlaceOpeningInterval{}).Error; err != nil {
+			return err
+		}
+		return recordPlaceHistory(tx, placeID, actorID, models.PlaceHistoryOpeningHours, "", "", "removed")
+	})
+}
+
+// Normalize validates the input and returns the opening hours it describes (PlaceID unset), with
+// the OSM form filled in for structured input and the structured form for OSM input.
+func (s *OpeningHoursService) Normalize(in OpeningHoursInput) (*models.PlaceOpeningHours, error) {
+	h := &models.PlaceOpeningHours{Timezone: strings.TrimSpace(in.Timezone), Weekly: in.Weekly, Exceptions: in.Exceptions}
+	if h.Timezone == "" {
+		h.Timezone = s.defaultTimezone
+	}
+	if osm := strings.TrimSpace(in.OSM); osm != "" {
+		if len(in.Weekly) > 0 || len(in.Exceptions) > 0 {
+			return nil, errors.Invalid("osm", errors.MsgExclusive, "other", "weekly/exceptions")
+		}
+		weekly, exceptions, err := ParseOSMOpeningHours(osm)
+		if err != nil {
+			return nil, err
+		}
+		h.Weekly, h.Exceptions = weekly, exceptions
+	}
+	if h.Weekly == nil {
+		h.Weekly = models.OpeningWeekJSON{}
+	}
+	if h.Exceptions == nil {
+		h.Exceptions = models.OpeningExceptionsJSON{}
+	}
+	if err := validateOpeningHours(h.Timezone, h.Weekly, h.Exceptions); err != nil {
+		return nil, err
+	}
+	h.OSM = FormatOSMOpeningHours(h.Weekly, h.Exceptions)
+	return h, nil
+}
+
+// setOpeningHours upserts h, rewrites its flattened intervals and records the change.
+func setOpeningHours(tx *gorm.DB, actorID *uuid.UUID, h *models.PlaceOpeningHours) error {
+	h.UpdatedBy = actorID
+	h.UpdatedAt = time.Now()
+	if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(h).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("place_id = ?", h.PlaceID).Delete(&models.PlaceOpeningInterval{}).Error; err != nil {
+		return err
+	}
+	if rows := openingIntervalRows(h.PlaceID, h.Weekly, h.Exceptions); len(rows) > 0 {
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+	}
+	return recordPlaceHistory(tx, h.PlaceID, actorID, models.PlaceHistoryOpeningHours, "", "", h.OSM)
+}
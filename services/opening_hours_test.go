@@ -0,0 +1,354 @@
+package services
+
+import (
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+)
+
+func iv(opens, closes string) models.OpeningInterval {
+	return models.OpeningInterval{Opens: opens, Closes: closes}
+}
+
+// fieldOf returns the field of a catalogue validation error, or "" for any other error.
+func fieldOf(err error) string {
+	var e *errors.Error
+	if !stderrors.As(err, &e) || !stderrors.Is(err, errors.ErrValidation) || len(e.Fields) == 0 {
+		return ""
+	}
+	return e.Fields[0].Field
+}
+
+func TestParseOSMOpeningHours(t *testing.T) {
+	allDay := []models.OpeningInterval{iv("00:00", "24:00")}
+	tests := []struct {
+		name           string
+		in             string
+		wantWeekly     models.OpeningWeekJSON
+		wantExceptions models.OpeningExceptionsJSON
+	}{
+		{
+			"24/7", "24/7",
+			models.OpeningWeekJSON{"mo": allDay, "tu": allDay, "we": allDay, "th": allDay, "fr": allDay, "sa": allDay, "su": allDay},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"weekday range and time list", "Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-12:00",
+			models.OpeningWeekJSON{
+				"mo": {iv("08:00", "12:00"), iv("13:00", "18:00")},
+				"tu": {iv("08:00", "12:00"), iv("13:00", "18:00")},
+				"we": {iv("08:00", "12:00"), iv("13:00", "18:00")},
+				"th": {iv("08:00", "12:00"), iv("13:00", "18:00")},
+				"fr": {iv("08:00", "12:00"), iv("13:00", "18:00")},
+				"sa": {iv("09:00", "12:00")},
+			},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"wrapping day range", "Fr-Mo 10:00-14:00",
+			models.OpeningWeekJSON{
+				"fr": {iv("10:00", "14:00")}, "sa": {iv("10:00", "14:00")},
+				"su": {iv("10:00", "14:00")}, "mo": {iv("10:00", "14:00")},
+			},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"day list", "Mo,We,Sa-Su 09:00-10:00",
+			models.OpeningWeekJSON{
+				"mo": {iv("09:00", "10:00")}, "we": {iv("09:00", "10:00")},
+				"sa": {iv("09:00", "10:00")}, "su": {iv("09:00", "10:00")},
+			},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"hours past midnight", "Fr 22:00-26:00",
+			models.OpeningWeekJSON{"fr": {iv("22:00", "02:00")}},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"overnight span", "Sa 20:00-03:30",
+			models.OpeningWeekJSON{"sa": {iv("20:00", "03:30")}},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"days without times are open all day", "Su",
+			models.OpeningWeekJSON{"su": allDay},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"later rule closes a day", "Mo-Su 08:00-18:00; Su off",
+			models.OpeningWeekJSON{
+				"mo": {iv("08:00", "18:00")}, "tu": {iv("08:00", "18:00")}, "we": {iv("08:00", "18:00")},
+				"th": {iv("08:00", "18:00")}, "fr": {iv("08:00", "18:00")}, "sa": {iv("08:00", "18:00")},
+			},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"rule without selector applies to every day", "10:00-11:00; Mo closed",
+			models.OpeningWeekJSON{
+				"tu": {iv("10:00", "11:00")}, "we": {iv("10:00", "11:00")}, "th": {iv("10:00", "11:00")},
+				"fr": {iv("10:00", "11:00")}, "sa": {iv("10:00", "11:00")}, "su": {iv("10:00", "11:00")},
+			},
+			models.OpeningExceptionsJSON{},
+		},
+		{
+			"date exceptions", "Mo-Fr 09:00-17:00; 2024 Dec 25 off; 2024 Dec 5 10:00-14:00",
+			models.OpeningWeekJSON{
+				"mo": {iv("09:00", "17:00")}, "tu": {iv("09:00", "17:00")}, "we": {iv("09:00", "17:00")},
+				"th": {iv("09:00", "17:00")}, "fr": {iv("09:00", "17:00")},
+			},
+			models.OpeningExceptionsJSON{
+				{Date: "2024-12-25", Intervals: []models.OpeningInterval{}},
+				{Date: "2024-12-05", Intervals: []models.OpeningInterval{iv("10:00", "14:00")}},
+			},
+		},
+		{
+			"repeated date keeps the last rule", "2024 Jan 01 off; 2024 Jan 01 12:00-13:00",
+			models.OpeningWeekJSON{},
+			models.OpeningExceptionsJSON{{Date: "2024-01-01", Intervals: []models.OpeningInterval{iv("12:00", "13:00")}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weekly, exceptions, err := ParseOSMOpeningHours(tt.in)
+			if err != nil {
+				t.Fatalf("ParseOSMOpeningHours(%q) error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(weekly, tt.wantWeekly) {
+				t.Errorf("weekly = %v, want %v", weekly, tt.wantWeekly)
+			}
+			if !reflect.DeepEqual(exceptions, tt.wantExceptions) {
+				t.Errorf("exceptions = %v, want %v", exceptions, tt.wantExceptions)
+			}
+		})
+	}
+}
+
+func TestParseOSMOpeningHoursErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"public holidays", "PH off"},
+		{"month range", "Jan-Mar 08:00-12:00"},
+		{"comment", `Mo 08:00-12:00 "by appointment"`},
+		{"invalid date", "2024 Feb 30 off"},
+		{"opening hour past midnight", "Mo 24:00-26:00"},
+		{"closing beyond next day", "Mo 22:00-49:00"},
+		{"empty span", "Mo 08:00-08:00"},
+		{"bad minutes", "Mo 08:75-12:00"},
+		{"open-ended", "Mo 08:00+"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseOSMOpeningHours(tt.in)
+			if got := fieldOf(err); got != "osm" {
+				t.Errorf("ParseOSMOpeningHours(%q) error = %v, want a field error on osm", tt.in, err)
+			}
+		})
+	}
+}
+
+func TestFormatOSMOpeningHours(t *testing.T) {
+	allDay := []models.OpeningInterval{iv("00:00", "24:00")}
+	tests := []struct {
+		name       string
+		weekly     models.OpeningWeekJSON
+		exceptions models.OpeningExceptionsJSON
+		want       string
+	}{
+		{"empty", nil, nil, "off"},
+		{
+			"24/7",
+			models.OpeningWeekJSON{"mo": allDay, "tu": allDay, "we": allDay, "th": allDay, "fr": allDay, "sa": allDay, "su": allDay},
+			nil, "24/7",
+		},
+		{
+			"all day with an exception is not 24/7",
+			models.OpeningWeekJSON{"mo": allDay, "tu": allDay, "we": allDay, "th": allDay, "fr": allDay, "sa": allDay, "su": allDay},
+			models.OpeningExceptionsJSON{{Date: "2024-12-25"}},
+			"Mo-Su 00:00-24:00; 2024 Dec 25 off",
+		},
+		{
+			"groups consecutive days",
+			models.OpeningWeekJSON{
+				"mo": {iv("08:00", "18:00")}, "tu": {iv("08:00", "18:00")}, "we": {iv("08:00", "18:00")},
+				"sa": {iv("09:00", "12:00"), iv("14:00", "16:00")},
+			},
+			nil, "Mo-We 08:00-18:00; Sa 09:00-12:00,14:00-16:00",
+		},
+		{
+			"same hours on separate days",
+			models.OpeningWeekJSON{"mo": {iv("08:00", "12:00")}, "tu": {iv("09:00", "10:00")}, "we": {iv("08:00", "12:00")}},
+			nil, "Mo 08:00-12:00; Tu 09:00-10:00; We 08:00-12:00",
+		},
+		{
+			"exceptions sorted by date",
+			models.OpeningWeekJSON{"fr": {iv("22:00", "02:00")}},
+			models.OpeningExceptionsJSON{
+				{Date: "2025-01-01", Intervals: []models.OpeningInterval{iv("12:00", "14:00")}},
+				{Date: "2024-12-31"},
+			},
+			"Fr 22:00-02:00; 2024 Dec 31 off; 2025 Jan 01 12:00-14:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatOSMOpeningHours(tt.weekly, tt.exceptions); got != tt.want {
+				t.Errorf("FormatOSMOpeningHours = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSMOpeningHoursRoundTrip(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // canonical form written back
+	}{
+		{"24/7", "24/7"},
+		{"Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-12:00", "Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-12:00"},
+		{"Fr-Mo 10:00-14:00", "Mo 10:00-14:00; Fr-Su 10:00-14:00"},
+		{"Fr 22:00-26:00", "Fr 22:00-02:00"},
+		{"Mo-Su 08:00-18:00; Su off", "Mo-Sa 08:00-18:00"},
+		{"Mo-Fr 09:00-17:00; 2024 Dec 25 off; 2024 Dec 5 10:00-14:00", "Mo-Fr 09:00-17:00; 2024 Dec 05 10:00-14:00; 2024 Dec 25 off"},
+		{"Su", "Su 00:00-24:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			weekly, exceptions, err := ParseOSMOpeningHours(tt.in)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.in, err)
+			}
+			got := FormatOSMOpeningHours(weekly, exceptions)
+			if got != tt.want {
+				t.Fatalf("format = %q, want %q", got, tt.want)
+			}
+			weekly2, exceptions2, err := ParseOSMOpeningHours(got)
+			if err != nil {
+				t.Fatalf("parse formatted %q: %v", got, err)
+			}
+			if !reflect.DeepEqual(weekly2, weekly) {
+				t.Errorf("weekly after round trip = %v, want %v", weekly2, weekly)
+			}
+			if FormatOSMOpeningHours(weekly2, exceptions2) != got {
+				t.Errorf("format is not stable for %q", got)
+			}
+		})
+	}
+}
+
+func TestValidateOpeningHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		timezone   string
+		weekly     models.OpeningWeekJSON
+		exceptions models.OpeningExceptionsJSON
+		wantField  string // "" = valid
+	}{
+		{"valid", "Europe/Paris", models.OpeningWeekJSON{"mo": {iv("08:00", "24:00")}, "fr": {iv("22:00", "02:00")}},
+			models.OpeningExceptionsJSON{{Date: "2024-12-25"}}, ""},
+		{"missing timezone", "", nil, nil, "timezone"},
+		{"local timezone", "Local", nil, nil, "timezone"},
+		{"unknown timezone", "Mars/Olympus_Mons", nil, nil, "timezone"},
+		{"unknown weekday", "UTC", models.OpeningWeekJSON{"xx": {iv("08:00", "09:00")}}, nil, "weekly"},
+		{"empty interval", "UTC", models.OpeningWeekJSON{"tu": {iv("08:00", "08:00")}}, nil, "weekly.tu"},
+		{"opens at 24:00", "UTC", models.OpeningWeekJSON{"tu": {iv("24:00", "02:00")}}, nil, "weekly.tu"},
+		{"closes after 24:00", "UTC", models.OpeningWeekJSON{"tu": {iv("22:00", "26:00")}}, nil, "weekly.tu"},
+		{"bad exception date", "UTC", nil, models.OpeningExceptionsJSON{{Date: "25/12/2024"}}, "exceptions.date"},
+		{"duplicate exception date", "UTC", nil, models.OpeningExceptionsJSON{{Date: "2024-12-25"}, {Date: "2024-12-25"}}, "exceptions.date"},
+		{"bad exception interval", "UTC", nil,
+			models.OpeningExceptionsJSON{{Date: "2024-12-24", Intervals: []models.OpeningInterval{iv("9:5", "12:00")}}}, "exceptions.2024-12-24"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOpeningHours(tt.timezone, tt.weekly, tt.exceptions)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := fieldOf(err); got != tt.wantField {
+				t.Errorf("error = %v (field %q), want a field error on %q", err, got, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestIsOpenAt(t *testing.T) {
+	// 2024-05-06 is a Monday.
+	h := &models.PlaceOpeningHours{
+		Timezone: "UTC",
+		Weekly: models.OpeningWeekJSON{
+			"mo": {iv("08:00", "12:00"), iv("14:00", "18:00")},
+			"fr": {iv("22:00", "02:00")},
+			"su": {iv("00:00", "24:00")},
+		},
+		Exceptions: models.OpeningExceptionsJSON{
+			{Date: "2024-05-10"}, // Friday closed, including its night
+			{Date: "2024-05-18", Intervals: []models.OpeningInterval{iv("10:00", "11:00")}}, // Saturday
+			{Date: "2024-05-22", Intervals: []models.OpeningInterval{iv("23:00", "01:00")}}, // Wednesday
+		},
+	}
+	tests := []struct {
+		name string
+		at   string
+		want bool
+	}{
+		{"monday morning", "2024-05-06T09:00:00Z", true},
+		{"monday opening minute", "2024-05-06T08:00:00Z", true},
+		{"monday closing minute is closed", "2024-05-06T12:00:00Z", false},
+		{"monday lunch break", "2024-05-06T13:00:00Z", false},
+		{"tuesday", "2024-05-07T09:00:00Z", false},
+		{"friday night", "2024-05-03T23:00:00Z", true},
+		{"after midnight into saturday", "2024-05-04T01:30:00Z", true},
+		{"overnight closing minute", "2024-05-04T02:00:00Z", false},
+		{"sunday all day", "2024-05-05T23:59:00Z", true},
+		{"24:00 does not run into monday", "2024-05-06T00:00:00Z", false},
+		{"closed friday exception", "2024-05-10T23:00:00Z", false},
+		{"closed friday exception drops its night", "2024-05-11T01:00:00Z", false},
+		{"saturday exception keeps friday's night", "2024-05-18T01:00:00Z", true},
+		{"saturday exception hours", "2024-05-18T10:30:00Z", true},
+		{"exception overnight span", "2024-05-23T00:30:00Z", true},
+		{"exception overnight closing", "2024-05-23T01:00:00Z", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := isOpenAt(h, at); got != tt.want {
+				t.Errorf("isOpenAt(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOpenAtTimezone(t *testing.T) {
+	h := &models.PlaceOpeningHours{
+		Timezone: "Asia/Tokyo",
+		Weekly:   models.OpeningWeekJSON{"mo": {iv("08:00", "12:00")}},
+	}
+	tests := []struct {
+		at   string
+		want bool
+	}{
+		{"2024-05-05T23:30:00Z", true},  // Monday 08:30 in Tokyo
+		{"2024-05-06T09:00:00Z", false}, // Monday 18:00 in Tokyo
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := isOpenAt(h, at); got != tt.want {
+			t.Errorf("isOpenAt(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	if isOpenAt(&models.PlaceOpeningHours{Timezone: "Mars/Olympus_Mons"}, time.Now()) {
+		t.Error("isOpenAt with an unknown timezone = true, want false")
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// permissionInvalidateChannel is the Postgres NOTIFY channel used to invalidate caches on other instances.
+// Payload is a user UUID, or "*" to drop every entry.
+const permissionInvalidateChannel = "rbac_invalidate"
+
+// defaultPermissionCacheTTL applies when PERMISSION_CACHE_TTL is unset or invalid.
+const defaultPermissionCacheTTL = 60 * time.Second
+
+// effectivePermissions is the cached permission set of one user.
+type effectivePermissions struct {
+	admin   bool
+	perms   map[string]bool
+	expires time.Time
+}
+
+// PermissionCache holds each user's effective permission set for a TTL.
+// Safe for concurrent use. Entries are dropped on role changes (see InvalidateUser / InvalidateAll).
+type PermissionCache struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]effectivePermissions
+	ttl     time.Duration
+	hits    atomic.Int64
+	misses  atomic.Int64
+}
+
+// NewPermissionCache returns an empty cache with the given TTL.
+func NewPermissionCache(ttl time.Duration) *PermissionCache {
+	return &PermissionCache{entries: make(map[uuid.UUID]effectivePermissions), ttl: ttl}
+}
+
+// defaultPermissionCache is shared by every PermissionService in the process so that
+// invalidation from role services reaches all middleware instances.
+var defaultPermissionCache = NewPermissionCache(permissionCacheTTLFromEnv())
+
+// DefaultPermissionCache returns the process-wide cache (for metrics and the NOTIFY listener).
+func DefaultPermissionCache() *PermissionCache {
+	return defaultPermissionCache
+}
+
+func permissionCacheTTLFromEnv() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("PERMISSION_CACHE_TTL")); err == nil && d >= 0 {
+		return d
+	}
+	return defaultPermissionCacheTTL
+}
+
+func (c *PermissionCache) get(userID uuid.UUID) (effectivePermissions, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[userID]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(e.expires) {
+		c.misses.Add(1)
+		return effectivePermissions{}, false
+	}
+	c.hits.Add(1)
+	return e, true
+}
+
+func (c *PermissionCache) put(userID uuid.UUID, e effectivePermissions) {
+	if c.ttl == 0 {
+		return
+	}
+	e.expires = time.Now().Add(c.ttl)
+	c.mu.Lock()
+	c.entries[userID] = e
+	c.mu.Unlock()
+}
+
+// InvalidateUser drops the cached permissions of one user.
+func (c *PermissionCache) InvalidateUser(userID uuid.UUID) {
+	c.mu.Lock()
+	delete(c.entries, userID)
+	c.mu.Unlock()
+}
+
+// InvalidateAll drops every cached entry.
+func (c *PermissionCache) InvalidateAll() {
+	c.mu.Lock()
+	c.entries = make(map[uuid.UUID]effectivePermissions)
+	c.mu.Unlock()
+}
+
+// PermissionCacheStats is the response shape for cache metrics.
+type PermissionCacheStats struct {
+	Entries int     `json:"entries"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+	TTL     string  `json:"ttl"`
+}
+
+// Stats returns hit / miss counters and the current entry count.
+func (c *PermissionCache) Stats() PermissionCacheStats {
+	c.mu.RLock()
+	n := len(c.entries)
+	c.mu.RUnlock()
+	hits, misses := c.hits.Load(), c.misses.Load()
+	var rate float64
+	if hits+misses > 0 {
+		rate = float64(hits) / float64(hits+misses)
+	}
+	return PermissionCacheStats{Entries: n, Hits: hits, Misses: misses, HitRate: rate, TTL: c.ttl.String()}
+}
+
+// invalidateUserPermissions drops the user's entry locally and notifies other instances. Inside a
+// permissionTransaction the local drop waits for the commit (as does the NOTIFY), so a read racing
+// the transaction cannot cache the permissions it is about to replace.
+func invalidateUserPermissions(db *gorm.DB, userID uuid.UUID) {
+	if p := pendingPermissionInvalidations(db); p != nil {
+		p.add(userID)
+	} else {
+		defaultPermissionCache.InvalidateUser(userID)
+	}
+	notifyPermissionInvalidation(db, userID.String())
+}
+
+// invalidateAllPermissions drops every entry locally and notifies other instances.
+func invalidateAllPermissions(db *gorm.DB) {
+	if p := pendingPermissionInvalidations(db); p != nil {
+		p.addAll()
+	} else {
+		defaultPermissionCache.InvalidateAll()
+	}
+	notifyPermissionInvalidation(db, "*")
+}
+
+// permissionInvalidations collects the local invalidations of one transaction until it commits.
+type permissionInvalidations struct {
+	mu    sync.Mutex
+	users []uuid.UUID
+	all   bool
+}
+
+func (p *permissionInvalidations) add(userID uuid.UUID) {
+	p.mu.Lock()
+	p.users = append(p.users, userID)
+	p.mu.Unlock()
+}
+
+func (p *permissionInvalidations) addAll() {
+	p.mu.Lock()
+	p.all = true
+	p.mu.Unlock()
+}
+
+func (p *permissionInvalidations) apply(c *PermissionCache) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.all {
+		c.InvalidateAll()
+		return
+	}
+	for _, id := range p.users {
+		c.InvalidateUser(id)
+	}
+}
+
+// openPermissionTransactions maps the connection of each running permissionTransaction (shared by
+// its savepoints and by services built on its tx) to the invalidations waiting for its commit.
+var openPermissionTransactions sync.Map
+
+func pendingPermissionInvalidations(db *gorm.DB) *permissionInvalidations {
+	if db.Statement == nil || db.Statement.ConnPool == nil {
+		return nil
+	}
+	if p, ok := openPermissionTransactions.Load(db.Statement.ConnPool); ok {
+		return p.(*permissionInvalidations)
+	}
+	return nil
+}
+
+// permissionTransaction runs fn in a transaction on db and applies the cache invalidations it made
+// once it has committed. Nested in another permissionTransaction it defers to the outermost one.
+func permissionTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	var pending *permissionInvalidations
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if pendingPermissionInvalidations(tx) != nil {
+			return fn(tx)
+		}
+		pending = &permissionInvalidations{}
+		openPermissionTransactions.Store(tx.Statement.ConnPool, pending)
+		defer openPermissionTransactions.Delete(tx.Statement.ConnPool)
+		return fn(tx)
+	})
+	if err == nil && pending != nil {
+		pending.apply(defaultPermissionCache)
+	}
+	return err
+}
+
+func notifyPermissionInvalidation(db *gorm.DB, payload string) {
+	if err := db.Exec("SELECT pg_notify(?, ?)", permissionInvalidateChannel, payload).Error; err != nil {
+		log.Printf("permission cache: notify failed: %v", err)
+	}
+}
+
+// ListenPermissionInvalidation LISTENs on the invalidation channel and applies notifications to
+// the default cache until ctx is done. Reconnects with backoff; on reconnect the whole cache is
+// dropped because notifications may have been missed.
+func ListenPermissionInvalidation(ctx context.Context, dsn string) {
+	listenNotifications(ctx, dsn, permissionInvalidateChannel, defaultPermissionCache.InvalidateAll, func(payload string) {
+		if payload == "*" {
+			defaultPermissionCache.InvalidateAll()
+			return
+		}
+		if id, err := uuid.Parse(payload); err == nil {
+			defaultPermissionCache.InvalidateUser(id)
+		}
+	})
+}
+
+// listenNotifications LISTENs on channel and passes each payload to apply until ctx is done.
+// Reconnects with backoff, calling reset first since notifications may have been missed.
+func listenNotifications(ctx context.Context, dsn, channel string, reset func(), apply func(payload string)) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := listenOnce(ctx, dsn, channel, apply)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("%s listener stopped: %v; retrying in %s", channel, err, backoff)
+		reset()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, dsn, channel string, apply func(payload string)) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		apply(n.Payload)
+	}
+}
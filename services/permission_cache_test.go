@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPermissionCacheTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wait    time.Duration
+		wantHit bool
+	}{
+		{"fresh entry hits", time.Minute, 0, true},
+		{"expired entry misses", 10 * time.Millisecond, 20 * time.Millisecond, false},
+		{"zero ttl disables caching", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewPermissionCache(tt.ttl)
+			id := uuid.New()
+			c.put(id, effectivePermissions{perms: map[string]bool{"places:read": true}})
+			time.Sleep(tt.wait)
+			e, ok := c.get(id)
+			if ok != tt.wantHit {
+				t.Fatalf("get hit = %v, want %v", ok, tt.wantHit)
+			}
+			if ok && !e.perms["places:read"] {
+				t.Errorf("cached permissions lost: %v", e.perms)
+			}
+		})
+	}
+}
+
+func TestPermissionCacheInvalidation(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		invalidate func(c *PermissionCache)
+		wantA      bool
+		wantB      bool
+	}{
+		{"nothing", func(c *PermissionCache) {}, true, true},
+		{"one user", func(c *PermissionCache) { c.InvalidateUser(a) }, false, true},
+		{"unknown user", func(c *PermissionCache) { c.InvalidateUser(uuid.New()) }, true, true},
+		{"all", func(c *PermissionCache) { c.InvalidateAll() }, false, false},
+		{"pending user", func(c *PermissionCache) {
+			p := &permissionInvalidations{}
+			p.add(b)
+			p.apply(c)
+		}, true, false},
+		{"pending all", func(c *PermissionCache) {
+			p := &permissionInvalidations{}
+			p.add(a)
+			p.addAll()
+			p.apply(c)
+		}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewPermissionCache(time.Minute)
+			c.put(a, effectivePermissions{})
+			c.put(b, effectivePermissions{})
+			tt.invalidate(c)
+			if _, ok := c.get(a); ok != tt.wantA {
+				t.Errorf("user a cached = %v, want %v", ok, tt.wantA)
+			}
+			if _, ok := c.get(b); ok != tt.wantB {
+				t.Errorf("user b cached = %v, want %v", ok, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestPermissionCacheStats(t *testing.T) {
+	c := NewPermissionCache(time.Minute)
+	a, b := uuid.New(), uuid.New()
+	c.put(a, effectivePermissions{})
+	c.get(a)
+	c.get(a)
+	c.get(a)
+	c.get(b)
+	got := c.Stats()
+	want := PermissionCacheStats{Entries: 1, Hits: 3, Misses: 1, HitRate: 0.75, TTL: "1m0s"}
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if empty := NewPermissionCache(time.Second).Stats(); empty.HitRate != 0 {
+		t.Errorf("empty cache hit rate = %v, want 0", empty.HitRate)
+	}
+}
+
+// BenchmarkHasPermission compares cached lookups with the query run on every call (TTL 0).
+// Needs a Postgres with the schema migrated: set TEST_DATABASE_URL.
+func BenchmarkHasPermission(b *testing.B) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	var userID uuid.UUID
+	if err := db.Raw("SELECT user_id FROM user_roles LIMIT 1").Scan(&userID).Error; err != nil {
+		b.Fatal(err)
+	}
+	if userID == uuid.Nil {
+		userID = uuid.New()
+	}
+	ctx := context.Background()
+	for _, bc := range []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{"cached", time.Minute},
+		{"uncached", 0},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			svc := &PermissionService{db: db, cache: NewPermissionCache(bc.ttl)}
+			if _, err := svc.HasPermission(ctx, userID, "places:read"); err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := svc.HasPermission(ctx, userID, "places:read"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
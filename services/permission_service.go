@@ -8,28 +8,64 @@ import (
 )
 
 // PermissionService checks whether a user has a given permission (via roles or admin).
+// Effective permission sets are cached per user in the process-wide PermissionCache.
 type PermissionService struct {
-	db *gorm.DB
+	db    *gorm.DB
+	cache *PermissionCache
 }
 
-// NewPermissionService returns a PermissionService using the given DB.
+// NewPermissionService returns a PermissionService using the given DB and the default cache.
 func NewPermissionService(db *gorm.DB) *PermissionService {
-	return &PermissionService{db: db}
+	return &PermissionService{db: db, cache: defaultPermissionCache}
 }
 
 // HasPermission returns true if the user has the given permission (either via a role that has it, or via the admin role).
 func (s *PermissionService) HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
-	var one int
+	e, err := s.effective(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return e.admin || e.perms[permission], nil
+}
+
+// IsAdmin returns true if the user holds the admin role.
+func (s *PermissionService) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	e, err := s.effective(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return e.admin, nil
+}
+
+// effective returns the user's cached permission set, loading it on a miss.
+func (s *PermissionService) effective(ctx context.Context, userID uuid.UUID) (effectivePermissions, error) {
+	if e, ok := s.cache.get(userID); ok {
+		return e, nil
+	}
+	type row struct {
+		Slug       string
+		Permission *string
+	}
+	var rows []row
 	err := s.db.WithContext(ctx).Raw(
-		`SELECT 1 FROM user_roles ur
+		`SELECT r.slug, rp.permission FROM user_roles ur
 		 JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
 		 LEFT JOIN role_permissions rp ON rp.role_id = ur.role_id
-		 WHERE ur.user_id = ? AND (rp.permission = ? OR r.slug = 'admin')
-		 LIMIT 1`,
-		userID, permission,
-	).Scan(&one).Error
+		 WHERE ur.user_id = ?`,
+		userID,
+	).Scan(&rows).Error
 	if err != nil {
-		return false, err
+		return effectivePermissions{}, err
+	}
+	e := effectivePermissions{perms: make(map[string]bool)}
+	for _, r := range rows {
+		if r.Slug == "admin" {
+			e.admin = true
+		}
+		if r.Permission != nil {
+			e.perms[*r.Permission] = true
+		}
 	}
-	return one == 1, nil
+	s.cache.put(userID, e)
+	return e, nil
 }
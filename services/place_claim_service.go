@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ownerRoleSlug is the role granted to users who become owner of a place.
+const ownerRoleSlug = "owner"
+
+// PlaceClaimService handles business-owner claims of existing places and ownership transfers.
+// Every change of Place.OwnerID is recorded in place_ownership_changes and the place history.
+type PlaceClaimService struct {
+	db *gorm.DB
+}
+
+// NewPlaceClaimService returns a PlaceClaimService.
+func NewPlaceClaimService(db *gorm.DB) *PlaceClaimService {
+	return &PlaceClaimService{db: db}
+}
+
+// ClaimInput is the body of POST /api/places/:id/claims.
+type ClaimInput struct {
+	Notes     string   `json:"notes"`
+	ProofURLs []string `json:"proof_urls"`
+}
+
+// Claim files a claim on the place. A user cannot claim a place they own or file a second
+// pending claim on the same place.
+func (s *PlaceClaimService) Claim(ctx context.Context, claimantID, placeID uuid.UUID, in ClaimInput) (*models.PlaceClaimRequest, error) {
+	in.Notes = strings.TrimSpace(in.Notes)
+	if in.Notes == "" && len(in.ProofURLs) == 0 {
+		return nil, errors.Invalid("notes", errors.MsgOneRequired, "other", "proof_urls")
+	}
+	if len(in.ProofURLs) > maxEvidenceURLs {
+		return nil, errors.Invalid("proof_urls", errors.MsgMaxItems, "max", strconv.Itoa(maxEvidenceURLs))
+	}
+	for _, raw := range in.ProofURLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.Invalid("proof_urls", errors.MsgURL)
+		}
+	}
+	var claim models.PlaceClaimRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if place.OwnerID != nil && *place.OwnerID == claimantID {
+			return errors.Msg(errors.ErrConflict, errors.MsgAlreadyOwner)
+		}
+		var n int64
+		if err := tx.Model(&models.PlaceClaimRequest{}).
+			Where("place_id = ? AND claimant_id = ? AND status = ?", placeID, claimantID, models.ClaimPending).
+			Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return errors.Msg(errors.ErrConflict, errors.MsgClaimPending)
+		}
+		claim = models.PlaceClaimRequest{
+			PlaceID:    placeID,
+			ClaimantID: claimantID,
+			Notes:      in.Notes,
+			ProofURLs:  models.StringListJSON(in.ProofURLs),
+			Status:     models.ClaimPending,
+		}
+		if err := tx.Create(&claim).Error; err != nil {
+			return err
+		}
+		return recordPlaceHistory(tx, placeID, &claimantID, models.PlaceHistoryClaimRequest, "", "", in.Notes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// List returns claims filtered by status (empty = all), oldest first.
+func (s *PlaceClaimService) List(ctx context.Context, status string, page, limit int) ([]models.PlaceClaimRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.PlaceClaimRequest{})
+	switch status {
+	case "":
+	case models.ClaimPending, models.ClaimApproved, models.ClaimRejected:
+		q = q.Where("status = ?", status)
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "pending, approved, rejected")
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.PlaceClaimRequest
+	err := q.Preload("Place").Order("created_at ASC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// Approve makes the claimant owner of the place, assigns them the owner role and rejects the
+// other pending claims on the place.
+func (s *PlaceClaimService) Approve(ctx context.Context, reviewerID, claimID uuid.UUID, notes string) (*models.PlaceClaimRequest, error) {
+	notes = strings.TrimSpace(notes)
+	var claim models.PlaceClaimRequest
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := s.loadPending(tx, claimID, reviewerID, &claim); err != nil {
+			return err
+		}
+		if err := s.review(tx, &claim, reviewerID, models.ClaimApproved, notes); err != nil {
+			return err
+		}
+		if err := s.changeOwner(ctx, tx, claim.PlaceID, claim.ClaimantID, reviewerID, models.OwnershipClaim, &claim.ID, notes); err != nil {
+			return err
+		}
+		return tx.Model(&models.PlaceClaimRequest{}).
+			Where("place_id = ? AND status = ? AND id <> ?", claim.PlaceID, models.ClaimPending, claim.ID).
+			Updates(map[string]interface{}{
+				"status":       models.ClaimRejected,
+				"reviewer_id":  reviewerID,
+				"review_notes": "another claim on this place was approved",
+				"reviewed_at":  time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Reject declines the claim; notes are required.
+func (s *PlaceClaimService) Reject(ctx context.Context, reviewerID, claimID uuid.UUID, notes string) (*models.PlaceClaimRequest, error) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return nil, errors.Invalid("notes", errors.MsgRequiredToReject)
+	}
+	var claim models.PlaceClaimRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.loadPending(tx, claimID, reviewerID, &claim); err != nil {
+			return err
+		}
+		if err := s.review(tx, &claim, reviewerID, models.ClaimRejected, notes); err != nil {
+			return err
+		}
+		return recordPlaceHistory(tx, claim.PlaceID, &reviewerID, models.PlaceHistoryClaimReject, "", "", notes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Transfer hands the place to another user (UUID or email), who is assigned the owner role.
+// Only the current owner or an admin may transfer a place.
+func (s *PlaceClaimService) Transfer(ctx context.Context, actorID, placeID uuid.UUID, toRef, notes string) error {
+	return permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Select("owner_id").Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if place.OwnerID == nil || *place.OwnerID != actorID {
+			admin, err := isAdminUser(tx, actorID)
+			if err != nil {
+				return err
+			}
+			if !admin {
+				return errors.Msg(errors.ErrForbidden, errors.MsgNotPlaceOwner)
+			}
+		}
+		toID, err := resolveUserRef(tx, strings.TrimSpace(toRef))
+		if err != nil {
+			return err
+		}
+		return s.changeOwner(ctx, tx, placeID, toID, actorID, models.OwnershipTransfer, nil, strings.TrimSpace(notes))
+	})
+}
+
+// Ownership returns the ownership history of the place, newest first.
+func (s *PlaceClaimService) Ownership(ctx context.Context, placeID uuid.UUID) ([]models.PlaceOwnershipChange, error) {
+	var list []models.PlaceOwnershipChange
+	err := s.db.WithContext(ctx).Where("place_id = ?", placeID).Order("created_at DESC").Find(&list).Error
+	return list, err
+}
+
+func (s *PlaceClaimService) loadPending(tx *gorm.DB, claimID, reviewerID uuid.UUID, claim *models.PlaceClaimRequest) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", claimID).First(claim).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrRequestNotFound
+		}
+		return err
+	}
+	if claim.Status != models.ClaimPending {
+		return errors.ErrRequestNotPending
+	}
+	if claim.ClaimantID == reviewerID {
+		return errors.ErrSelfReview
+	}
+	return nil
+}
+
+func (s *PlaceClaimService) review(tx *gorm.DB, claim *models.PlaceClaimRequest, reviewerID uuid.UUID, status, notes string) error {
+	now := time.Now()
+	claim.Status, claim.ReviewerID, claim.ReviewNotes, claim.ReviewedAt = status, &reviewerID, notes, &now
+	return tx.Model(claim).Updates(map[string]interface{}{
+		"status":       status,
+		"reviewer_id":  reviewerID,
+		"review_notes": notes,
+		"reviewed_at":  now,
+	}).Error
+}
+
+// changeOwner sets Place.OwnerID, records the change and assigns the owner role to the new owner.
+// The previous owner loses the owner role once they own no other place.
+func (s *PlaceClaimService) changeOwner(ctx context.Context, tx *gorm.DB, placeID, toID, actorID uuid.UUID, reason string, claimID *uuid.UUID, notes string) error {
+	var place models.Place
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrPlaceNotFound
+		}
+		return err
+	}
+	if place.OwnerID != nil && *place.OwnerID == toID {
+		return errors.Msg(errors.ErrConflict, errors.MsgUserOwnsPlace)
+	}
+	if err := tx.Model(&place).Update("owner_id", toID).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&models.PlaceOwnershipChange{
+		PlaceID:     placeID,
+		FromOwnerID: place.OwnerID,
+		ToOwnerID:   &toID,
+		ActorID:     actorID,
+		Reason:      reason,
+		ClaimID:     claimID,
+		Notes:       notes,
+	}).Error; err != nil {
+		return err
+	}
+	if err := recordPlaceHistory(tx, placeID, &actorID, models.PlaceHistoryOwnerChange, "", "", reason+": "+toID.String()); err != nil {
+		return err
+	}
+	var role models.Role
+	if err := tx.Where("slug = ?", ownerRoleSlug).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrRoleNotFound
+		}
+		return err
+	}
+	if _, err := NewUserRoleService(tx).Assign(ctx, actorID, toID, role.ID); err != nil {
+		return err
+	}
+	if place.OwnerID == nil {
+		return nil
+	}
+	var owned int64
+	if err := tx.Model(&models.Place{}).Where("owner_id = ?", *place.OwnerID).Count(&owned).Error; err != nil {
+		return err
+	}
+	if owned > 0 {
+		return nil
+	}
+	err := NewUserRoleService(tx).Unassign(ctx, actorID, *place.OwnerID, role.ID)
+	if stderrors.Is(err, errors.ErrAssignmentNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultDuplicateRadius     = 150.0 // meters
+	defaultDuplicateSimilarity = 0.5
+	earthRadiusMeters          = 6371000.0
+	metersPerDegreeLat         = 111320.0
+)
+
+// PlaceDuplicateService finds likely duplicate places (similar name close by) and merges them.
+type PlaceDuplicateService struct {
+	db         *gorm.DB
+	radius     float64
+	similarity float64
+}
+
+// NewPlaceDuplicateService returns a PlaceDuplicateService configured from the environment:
+// DUPLICATE_RADIUS_METERS (default 150) and DUPLICATE_NAME_SIMILARITY (trigram similarity
+// between 0 and 1, default 0.5).
+func NewPlaceDuplicateService(db *gorm.DB) *PlaceDuplicateService {
+	s := &PlaceDuplicateService{db: db, radius: defaultDuplicateRadius, similarity: defaultDuplicateSimilarity}
+	if f, err := strconv.ParseFloat(os.Getenv("DUPLICATE_RADIUS_METERS"), 64); err == nil && f > 0 {
+		s.radius = f
+	}
+	if f, err := strconv.ParseFloat(os.Getenv("DUPLICATE_NAME_SIMILARITY"), 64); err == nil && f > 0 && f <= 1 {
+		s.similarity = f
+	}
+	return s
+}
+
+// DuplicateCandidate is an existing place that looks like the one being checked.
+type DuplicateCandidate struct {
+	Place          models.Place `json:"place"`
+	DistanceMeters float64      `json:"distance_meters"`
+	NameSimilarity float64      `json:"name_similarity"`
+}
+
+// DuplicatePair is one entry of the duplicate report.
+type DuplicatePair struct {
+	A              models.Place `json:"a"`
+	B              models.Place `json:"b"`
+	DistanceMeters float64      `json:"distance_meters"`
+	NameSimilarity float64      `json:"name_similarity"`
+}
+
+// Candidates returns places within the radius of (lat, lon) whose name or local name is similar
+// to name / nameLocal, most similar first.
+func (s *PlaceDuplicateService) Candidates(ctx context.Context, name, nameLocal string, lat, lon float64) ([]DuplicateCandidate, error) {
+	dLat := s.radius / metersPerDegreeLat
+	dLon := dLat / math.Max(math.Cos(lat*math.Pi/180), 0.01)
+	var nearby []models.Place
+	if err := s.db.WithContext(ctx).
+		Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", lat-dLat, lat+dLat, lon-dLon, lon+dLon).
+		Find(&nearby).Error; err != nil {
+		return nil, err
+	}
+	out := []DuplicateCandidate{}
+	for _, p := range nearby {
+		dist := distanceMeters(lat, lon, p.Latitude, p.Longitude)
+		sim := placeNameSimilarity(name, nameLocal, p.Name, p.Name_local)
+		if dist <= s.radius && sim >= s.similarity {
+			out = append(out, DuplicateCandidate{Place: p, DistanceMeters: math.Round(dist), NameSimilarity: sim})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].NameSimilarity != out[j].NameSimilarity {
+			return out[i].NameSimilarity > out[j].NameSimilarity
+		}
+		return out[i].DistanceMeters < out[j].DistanceMeters
+	})
+	return out, nil
+}
+
+// Report lists pairs of live places that look like duplicates, most similar first.
+func (s *PlaceDuplicateService) Report(ctx context.Context, page, limit int) ([]DuplicatePair, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	type row struct {
+		AID        uuid.UUID `gorm:"column:a_id"`
+		BID        uuid.UUID `gorm:"column:b_id"`
+		AName      string    `gorm:"column:a_name"`
+		ANameLocal string    `gorm:"column:a_name_local"`
+		BName      string    `gorm:"column:b_name"`
+		BNameLocal string    `gorm:"column:b_name_local"`
+		ALat       float64   `gorm:"column:a_lat"`
+		ALon       float64   `gorm:"column:a_lon"`
+		BLat       float64   `gorm:"column:b_lat"`
+		BLon       float64   `gorm:"column:b_lon"`
+	}
+	var rows []row
+	// Cheap bounding-box join in SQL; exact distance and name similarity are checked below.
+	dLat := s.radius / metersPerDegreeLat
+	err := s.db.WithContext(ctx).Raw(`
+		SELECT a.id AS a_id, b.id AS b_id, a.name AS a_name, a.name_local AS a_name_local,
+		       b.name AS b_name, b.name_local AS b_name_local,
+		       a.latitude AS a_lat, a.longitude AS a_lon, b.latitude AS b_lat, b.longitude AS b_lon
+		FROM places a
+		JOIN places b ON a.id < b.id AND b.deleted_at IS NULL
+		 AND ABS(a.latitude - b.latitude) <= ?
+		 AND ABS(a.longitude - b.longitude) * GREATEST(COS(RADIANS(a.latitude)), 0.01) <= ?
+		WHERE a.deleted_at IS NULL`, dLat, dLat).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	pairs := []DuplicatePair{}
+	for _, r := range rows {
+		dist := distanceMeters(r.ALat, r.ALon, r.BLat, r.BLon)
+		sim := placeNameSimilarity(r.AName, r.ANameLocal, r.BName, r.BNameLocal)
+		if dist <= s.radius && sim >= s.similarity {
+			pairs = append(pairs, DuplicatePair{
+				A:              models.Place{ID: r.AID},
+				B:              models.Place{ID: r.BID},
+				DistanceMeters: math.Round(dist),
+				NameSimilarity: sim,
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].NameSimilarity != pairs[j].NameSimilarity {
+			return pairs[i].NameSimilarity > pairs[j].NameSimilarity
+		}
+		return pairs[i].DistanceMeters < pairs[j].DistanceMeters
+	})
+	total := int64(len(pairs))
+	start, end := (page-1)*limit, page*limit
+	if start > len(pairs) {
+		start = len(pairs)
+	}
+	if end > len(pairs) {
+		end = len(pairs)
+	}
+	pairs = pairs[start:end]
+	ids := make([]uuid.UUID, 0, 2*len(pairs))
+	for _, p := range pairs {
+		ids = append(ids, p.A.ID, p.B.ID)
+	}
+	if len(ids) > 0 {
+		var places []models.Place
+		if err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&places).Error; err != nil {
+			return nil, 0, err
+		}
+		byID := make(map[uuid.UUID]models.Place, len(places))
+		for _, p := range places {
+			byID[p.ID] = p
+		}
+		for i := range pairs {
+			pairs[i].A, pairs[i].B = byID[pairs[i].A.ID], byID[pairs[i].B.ID]
+		}
+	}
+	return pairs, total, nil
+}
+
+// Merge folds mergedID into survivorID: detail keys missing on the survivor are copied over,
+// every plan item and review is repointed, the merged place is soft-deleted and a redirect is left
+// behind. A user who reviewed both places keeps only their review of the survivor.
+func (s *PlaceDuplicateService) Merge(ctx context.Context, actorID, mergedID, survivorID uuid.UUID) (*models.Place, error) {
+	if mergedID == survivorID {
+		return nil, errors.Invalid("into", errors.MsgSameAs, "other", "id")
+	}
+	var survivor models.Place
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var places []models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uuid.UUID{mergedID, survivorID}).Order("id").Find(&places).Error; err != nil {
+			return err
+		}
+		if len(places) != 2 {
+			return errors.ErrPlaceNotFound
+		}
+		merged := places[0]
+		survivor = places[1]
+		if merged.ID != mergedID {
+			merged, survivor = survivor, merged
+		}
+		details := map[string]interface{}{}
+		for k, v := range merged.Details {
+			if _, ok := survivor.Details[k]; !ok {
+				details[k] = v
+			}
+		}
+		if len(details) > 0 {
+			changes := map[string]interface{}{"details": details}
+			if err := applyPlaceChanges(tx, &survivor, changes, &actorID, "merged from "+mergedID.String()); err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Model(&models.PlanItem{}).Where("place_id = ?", mergedID).
+			Update("place_id", survivorID).Error; err != nil {
+			return err
+		}
+		// Reviews follow, except those whose author already reviewed the survivor (one review per
+		// user and place): those are deleted rather than left behind on the deleted place.
+		reviewed := tx.Model(&models.PlaceReview{}).Select("author_id").Where("place_id = ?", survivorID)
+		if err := tx.Model(&models.PlaceReview{}).Where("place_id = ? AND author_id NOT IN (?)", mergedID, reviewed).
+			Update("place_id", survivorID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("place_id = ?", mergedID).Delete(&models.PlaceReview{}).Error; err != nil {
+			return err
+		}
+		if err := updatePlaceRating(tx, survivorID); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PlaceRedirect{}).Where("to_id = ?", mergedID).
+			Update("to_id", survivorID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.PlaceRedirect{FromID: mergedID, ToID: survivorID, MergedBy: actorID}).Error; err != nil {
+			return err
+		}
+		if err := ensureBaselineRevision(tx, &merged); err != nil {
+			return err
+		}
+		if err := tx.Delete(&merged).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &merged)
+		if err := recordPlaceRevision(tx, mergedID, &actorID, models.RevisionDelete); err != nil {
+			return err
+		}
+		if err := recordPlaceHistory(tx, mergedID, &actorID, models.PlaceHistoryMerge, "", "", "merged into "+survivorID.String()); err != nil {
+			return err
+		}
+		if err := recordPlaceHistory(tx, survivorID, &actorID, models.PlaceHistoryMerge, "", "", "merged from "+mergedID.String()); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", survivorID).First(&survivor).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &survivor, nil
+}
+
+// placeRedirect returns the place a merged place ID now points to, if any.
+func placeRedirect(db *gorm.DB, placeID uuid.UUID) (*uuid.UUID, error) {
+	var r models.PlaceRedirect
+	if err := db.Where("from_id = ?", placeID).First(&r).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &r.ToID, nil
+}
+
+// placeNameSimilarity is the best trigram similarity between any name of place a and any name of
+// place b (empty names are skipped).
+func placeNameSimilarity(aName, aLocal, bName, bLocal string) float64 {
+	best := 0.0
+	for _, x := range []string{aName, aLocal} {
+		for _, y := range []string{bName, bLocal} {
+			if strings.TrimSpace(x) == "" || strings.TrimSpace(y) == "" {
+				continue
+			}
+			best = math.Max(best, trigramSimilarity(x, y))
+		}
+	}
+	return math.Round(best*100) / 100
+}
+
+// trigramSimilarity works like pg_trgm's similarity(): the share of distinct trigrams (of the
+// lower-cased words, padded with spaces) the two strings have in common.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// distanceMeters is the haversine distance between two WGS84 points.
+func distanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrigramSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"word", "two words", 4.0 / 11}, // the pg_trgm documentation example
+		{"Cafe Central", "cafe central", 1},
+		{"Cafe, Central!", "cafe central", 1},
+		{"Central Cafe", "Cafe Central", 1},
+		{"abc", "xyz", 0},
+		{"", "cafe", 0},
+		{"!!", "!!", 0},
+		{"كافيه النيل", "كافيه النيل", 1},
+	}
+	for _, tt := range tests {
+		got := trigramSimilarity(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("trigramSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if back := trigramSimilarity(tt.b, tt.a); back != got {
+			t.Errorf("trigramSimilarity is not symmetric for %q, %q: %v vs %v", tt.a, tt.b, got, back)
+		}
+	}
+}
+
+func TestPlaceNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name                         string
+		aName, aLocal, bName, bLocal string
+		want                         float64
+	}{
+		{"same name", "Nile Cafe", "", "nile cafe", "", 1},
+		{"local name matches", "Nile Cafe", "كافيه النيل", "Cafe El Nil", "كافيه النيل", 1},
+		{"local against name", "Word", "", "Other", "two words", 0.36},
+		{"empty names skipped", "", "", "", "", 0},
+		{"different", "Bakery", "", "Pharmacy", "", 0},
+		{"shared word", "Nile Bakery", "", "Nile Pharmacy", "", 0.24}, // 5 of 21 trigrams, rounded
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := placeNameSimilarity(tt.aName, tt.aLocal, tt.bName, tt.bLocal); got != tt.want {
+				t.Errorf("placeNameSimilarity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
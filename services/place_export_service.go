@@ -0,0 +1,299 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Place export formats.
+const (
+	ExportFormatGeoJSON = "geojson"
+	ExportFormatCSV     = "csv"
+	ExportFormatKML     = "kml"
+)
+
+// placeExportColumns are the fixed columns of a CSV export, before the details columns.
+var placeExportColumns = []string{
+	"id", "name", "name_local", "description", "address", "latitude", "longitude",
+	"place_type", "verification_status",
+}
+
+// exportDetailColumn names the column / property of a details key. Keys that clash with a fixed
+// column, or that the import would read as a place field, are exported as "details.<key>".
+func exportDetailColumn(key string) string {
+	if _, ok := importFieldAliases[strings.ToLower(key)]; ok {
+		return "details." + key
+	}
+	for _, c := range placeExportColumns {
+		if c == key {
+			return "details." + key
+		}
+	}
+	return key
+}
+
+// PlaceExportService streams filtered places as GeoJSON, CSV or KML.
+type PlaceExportService struct {
+	db     *gorm.DB
+	places *PlaceService
+}
+
+// NewPlaceExportService returns a PlaceExportService.
+func NewPlaceExportService(db *gorm.DB) *PlaceExportService {
+	return &PlaceExportService{db: db, places: NewPlaceService(db)}
+}
+
+// PlaceExport is a prepared export: the filter and format are validated and the details columns
+// are known, so WriteTo can stream the rows.
+type PlaceExport struct {
+	ContentType string
+	Extension   string
+
+	db      *gorm.DB
+	query   *gorm.DB
+	format  string
+	slugs   map[uuid.UUID]string
+	columns []FormField // details columns, from the form schemas of the exported place types
+}
+
+// Prepare validates the format and filter and collects the details columns: the form fields of
+// the filtered place type, or of every place type when the export is not limited to one.
+func (s *PlaceExportService) Prepare(ctx context.Context, f PlaceFilter, format string) (*PlaceExport, error) {
+	exp := &PlaceExport{db: s.db, format: format}
+	switch format {
+	case ExportFormatGeoJSON:
+		exp.ContentType, exp.Extension = "application/geo+json", "geojson"
+	case ExportFormatCSV:
+		exp.ContentType, exp.Extension = "text/csv; charset=utf-8", "csv"
+	case ExportFormatKML:
+		exp.ContentType, exp.Extension = "application/vnd.google-earth.kml+xml", "kml"
+	default:
+		return nil, errors.Invalid("format", errors.MsgOneOf, "values", "geojson, csv, kml")
+	}
+	q, err := s.places.filtered(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	exp.query = q
+	var types []models.PlaceType
+	if err := s.db.WithContext(ctx).Order("slug").Find(&types).Error; err != nil {
+		return nil, err
+	}
+	exp.slugs = make(map[uuid.UUID]string, len(types))
+	seen := map[string]bool{}
+	for _, pt := range types {
+		exp.slugs[pt.ID] = pt.Slug
+		if f.PlaceType != "" && f.PlaceType != pt.Slug && f.PlaceType != pt.ID.String() {
+			continue
+		}
+		for _, field := range FormFields(pt.FormSchema) {
+			if !seen[field.Key] {
+				seen[field.Key] = true
+				exp.columns = append(exp.columns, field)
+			}
+		}
+	}
+	return exp, nil
+}
+
+// WriteTo streams the places to w, reading them from the database in batches.
+func (e *PlaceExport) WriteTo(ctx context.Context, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	var write func(p *models.Place) error
+	var finish func() error
+	switch e.format {
+	case ExportFormatGeoJSON:
+		write, finish = e.geoJSONWriter(bw)
+	case ExportFormatCSV:
+		write, finish = e.csvWriter(bw)
+	default:
+		write, finish = e.kmlWriter(bw)
+	}
+	rows, err := e.query.WithContext(ctx).Order("created_at, id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p models.Place
+		if err := e.db.ScanRows(rows, &p); err != nil {
+			return err
+		}
+		if err := write(&p); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// properties returns the exported attributes of p: the fixed columns and the details (named by
+// exportDetailColumn). For GeoJSON and KML details keys outside the form schemas are kept as well.
+func (e *PlaceExport) properties(p *models.Place, schemaOnly bool) ([]string, map[string]interface{}) {
+	props := map[string]interface{}{
+		"id":                  p.ID.String(),
+		"name":                p.Name,
+		"name_local":          p.Name_local,
+		"description":         p.Description,
+		"address":             p.Address,
+		"latitude":            p.Latitude,
+		"longitude":           p.Longitude,
+		"place_type":          e.slugs[p.PlaceTypeID],
+		"verification_status": p.VerificationStatus,
+	}
+	keys := append([]string{}, placeExportColumns...)
+	for _, c := range e.columns {
+		col := exportDetailColumn(c.Key)
+		keys = append(keys, col)
+		if v, ok := p.Details[c.Key]; ok {
+			props[col] = v
+		}
+	}
+	if !schemaOnly {
+		var extra []string
+		for k, v := range p.Details {
+			col := exportDetailColumn(k)
+			if _, ok := props[col]; !ok {
+				props[col] = v
+				extra = append(extra, col)
+			}
+		}
+		sort.Strings(extra)
+		keys = append(keys, extra...)
+	}
+	return keys, props
+}
+
+func (e *PlaceExport) geoJSONWriter(w *bufio.Writer) (func(*models.Place) error, func() error) {
+	first := true
+	_, _ = w.WriteString(`{"type":"FeatureCollection","features":[`)
+	write := func(p *models.Place) error {
+		_, props := e.properties(p, false)
+		delete(props, "latitude")
+		delete(props, "longitude")
+		b, err := json.Marshal(map[string]interface{}{
+			"type":       "Feature",
+			"id":         p.ID.String(),
+			"geometry":   map[string]interface{}{"type": "Point", "coordinates": []float64{p.Longitude, p.Latitude}},
+			"properties": props,
+		})
+		if err != nil {
+			return err
+		}
+		if !first {
+			_ = w.WriteByte(',')
+		}
+		first = false
+		_, err = w.Write(b)
+		return err
+	}
+	finish := func() error {
+		_, err := w.WriteString("]}\n")
+		return err
+	}
+	return write, finish
+}
+
+func (e *PlaceExport) csvWriter(w *bufio.Writer) (func(*models.Place) error, func() error) {
+	cw := csv.NewWriter(w)
+	header := append([]string{}, placeExportColumns...)
+	for _, c := range e.columns {
+		header = append(header, exportDetailColumn(c.Key))
+	}
+	headerErr := cw.Write(header)
+	write := func(p *models.Place) error {
+		if headerErr != nil {
+			return headerErr
+		}
+		keys, props := e.properties(p, true)
+		record := make([]string, len(keys))
+		for i, k := range keys {
+			record[i] = exportCell(props[k])
+		}
+		return cw.Write(record)
+	}
+	finish := func() error {
+		cw.Flush()
+		if headerErr != nil {
+			return headerErr
+		}
+		return cw.Error()
+	}
+	return write, finish
+}
+
+func (e *PlaceExport) kmlWriter(w *bufio.Writer) (func(*models.Place) error, func() error) {
+	_, _ = w.WriteString(xml.Header + `<kml xmlns="http://www.opengis.net/kml/2.2"><Document>` + "\n")
+	write := func(p *models.Place) error {
+		keys, props := e.properties(p, false)
+		w.WriteString("<Placemark><name>")
+		_ = xml.EscapeText(w, []byte(p.Name))
+		w.WriteString("</name><description>")
+		_ = xml.EscapeText(w, []byte(p.Description))
+		w.WriteString("</description><ExtendedData>")
+		for _, k := range keys {
+			if k == "name" || k == "description" || k == "latitude" || k == "longitude" {
+				continue
+			}
+			w.WriteString(`<Data name="`)
+			_ = xml.EscapeText(w, []byte(k))
+			w.WriteString(`"><value>`)
+			_ = xml.EscapeText(w, []byte(exportCell(props[k])))
+			w.WriteString("</value></Data>")
+		}
+		w.WriteString("</ExtendedData><Point><coordinates>")
+		w.WriteString(strconv.FormatFloat(p.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(p.Latitude, 'f', -1, 64))
+		_, err := w.WriteString("</coordinates></Point></Placemark>\n")
+		return err
+	}
+	finish := func() error {
+		_, err := w.WriteString("</Document></kml>\n")
+		return err
+	}
+	return write, finish
+}
+
+// exportCell renders a value for a CSV cell or KML Data value. Lists are joined with "|", the
+// separator the place import splits multiselect values on.
+func exportCell(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case []interface{}:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			parts[i] = exportCell(item)
+		}
+		return strings.Join(parts, "|")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceHistoryService reads the append-only place history.
+type PlaceHistoryService struct {
+	db *gorm.DB
+}
+
+// NewPlaceHistoryService returns a PlaceHistoryService.
+func NewPlaceHistoryService(db *gorm.DB) *PlaceHistoryService {
+	return &PlaceHistoryService{db: db}
+}
+
+// List returns the place's history, newest first.
+func (s *PlaceHistoryService) List(ctx context.Context, placeID uuid.UUID, page, limit int) ([]models.PlaceHistory, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	var n int64
+	if err := s.db.WithContext(ctx).Unscoped().Model(&models.Place{}).Where("id = ?", placeID).Count(&n).Error; err != nil {
+		return nil, 0, err
+	}
+	if n == 0 {
+		return nil, 0, errors.ErrPlaceNotFound
+	}
+	q := s.db.WithContext(ctx).Model(&models.PlaceHistory{}).Where("place_id = ?", placeID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.PlaceHistory
+	err := q.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// recordPlaceHistory appends a history entry inside the caller's transaction.
+func recordPlaceHistory(tx *gorm.DB, placeID uuid.UUID, actorID *uuid.UUID, action, from, to, notes string) error {
+	return tx.Create(&models.PlaceHistory{
+		PlaceID:    placeID,
+		ActorID:    actorID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   to,
+		Notes:      notes,
+	}).Error
+}
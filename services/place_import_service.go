@@ -0,0 +1,584 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxImportSize is the largest accepted import file.
+	MaxImportSize = 20 << 20
+	// maxImportErrors caps the stored per-row error report; FailedRows still counts every failure.
+	maxImportErrors = 1000
+	// importProgressEvery is how many rows are processed between progress updates.
+	importProgressEvery  = 100
+	importWorkerInterval = time.Minute
+	// staleImportAfter is how long a running import may go without a progress update before the
+	// worker fails it (the instance running it probably stopped).
+	staleImportAfter = 15 * time.Minute
+)
+
+// importFieldAliases maps lower-cased source column names to place fields for the default mapping.
+var importFieldAliases = map[string]string{
+	"name":        "name",
+	"name_local":  "name_local",
+	"description": "description",
+	"address":     "address",
+	"latitude":    "latitude",
+	"lat":         "latitude",
+	"longitude":   "longitude",
+	"lon":         "longitude",
+	"lng":         "longitude",
+	// OSM opening_hours syntax, in the row's timezone (PLACE_DEFAULT_TIMEZONE when absent)
+	"opening_hours": "opening_hours",
+	"timezone":      "timezone",
+}
+
+// PlaceImportService imports places in bulk from CSV or GeoJSON as background jobs.
+type PlaceImportService struct {
+	db     *gorm.DB
+	places *PlaceService
+	dups   *PlaceDuplicateService
+	hours  *OpeningHoursService
+}
+
+// NewPlaceImportService returns a PlaceImportService.
+func NewPlaceImportService(db *gorm.DB) *PlaceImportService {
+	return &PlaceImportService{db: db, places: NewPlaceService(db), dups: NewPlaceDuplicateService(db), hours: NewOpeningHoursService(db)}
+}
+
+// ImportInput describes an import. Mapping maps targets ("name", "latitude", "details.wifi",
+// "opening_hours", ...)
+// to source columns / properties; when empty, columns named like place fields (lat, lon and lng
+// included) map to those fields and the others to details keys of the same name.
+type ImportInput struct {
+	PlaceTypeID        uuid.UUID
+	Format             string // csv | geojson
+	Mapping            map[string]string
+	DryRun             bool
+	SkipDuplicateCheck bool
+	Data               []byte
+}
+
+// importRecord is one parsed CSV row or GeoJSON feature.
+type importRecord struct {
+	Row      int
+	Values   map[string]interface{}
+	Lat, Lon *float64 // GeoJSON Point geometry
+	Err      string   // set when the record itself is unusable
+}
+
+// Submit stores the import and starts processing it in the background.
+func (s *PlaceImportService) Submit(ctx context.Context, userID uuid.UUID, in ImportInput) (*models.PlaceImport, error) {
+	imp, err := s.Create(ctx, userID, in)
+	if err != nil {
+		return nil, err
+	}
+	go s.runSafely(context.Background(), imp.ID)
+	return imp, nil
+}
+
+// runSafely runs the import, logging errors and failing the import if processing panics.
+func (s *PlaceImportService) runSafely(ctx context.Context, importID uuid.UUID) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("place import %s: panic: %v", importID, r)
+			if err := s.failRunning(ctx, importID, fmt.Sprintf("import crashed: %v", r)); err != nil {
+				log.Printf("place import %s: %v", importID, err)
+			}
+		}
+	}()
+	if err := s.Run(ctx, importID); err != nil {
+		log.Printf("place import %s: %v", importID, err)
+	}
+}
+
+// RunPlaceImportWorker starts pending imports whose goroutine was lost (e.g. to a restart) and fails
+// running imports without progress for staleImportAfter, clearing their payload. Rows imported
+// before the interruption are kept, so those imports are not restarted. Checks every minute until
+// ctx is done.
+func RunPlaceImportWorker(ctx context.Context, db *gorm.DB) {
+	s := NewPlaceImportService(db)
+	ticker := time.NewTicker(importWorkerInterval)
+	defer ticker.Stop()
+	for {
+		var stale []uuid.UUID
+		if err := db.WithContext(ctx).Model(&models.PlaceImport{}).
+			Where("status = ? AND updated_at < ?", models.ImportStatusRunning, time.Now().Add(-staleImportAfter)).
+			Pluck("id", &stale).Error; err != nil {
+			log.Printf("place import worker: list stale imports: %v", err)
+		}
+		for _, id := range stale {
+			if err := s.failRunning(ctx, id, "import was interrupted; rows processed before the interruption were kept"); err != nil {
+				log.Printf("place import %s: %v", id, err)
+			}
+		}
+		var pending []uuid.UUID
+		if err := db.WithContext(ctx).Model(&models.PlaceImport{}).
+			Where("status = ?", models.ImportStatusPending).Pluck("id", &pending).Error; err != nil {
+			log.Printf("place import worker: list pending imports: %v", err)
+		}
+		for _, id := range pending {
+			go s.runSafely(ctx, id)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Create validates the input (format, place type, mapping, file structure) and stores a pending
+// import. Use Submit to also start it, or Run to process it synchronously.
+func (s *PlaceImportService) Create(ctx context.Context, userID uuid.UUID, in ImportInput) (*models.PlaceImport, error) {
+	if in.Format != models.ImportFormatCSV && in.Format != models.ImportFormatGeoJSON {
+		return nil, errors.Invalid("format", errors.MsgOneOf, "values", "csv, geojson")
+	}
+	if len(in.Data) == 0 {
+		return nil, errors.Invalid("file", errors.MsgNotEmpty)
+	}
+	if len(in.Data) > MaxImportSize {
+		return nil, errors.Invalid("file", errors.MsgMaxSize, "max", strconv.Itoa(MaxImportSize>>20))
+	}
+	var pt models.PlaceType
+	if err := s.db.WithContext(ctx).Where("id = ?", in.PlaceTypeID).First(&pt).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.Invalid("place_type_id", errors.MsgUnknown, "value", in.PlaceTypeID.String())
+		}
+		return nil, err
+	}
+	fields := FormFields(pt.FormSchema)
+	for target := range in.Mapping {
+		if err := validateImportTarget(target, fields); err != nil {
+			return nil, err
+		}
+	}
+	records, columns, err := parseImport(in.Format, in.Data)
+	if err != nil {
+		return nil, errors.Invalid("file", errors.MsgInvalidReason, "reason", err.Error())
+	}
+	if in.Format == models.ImportFormatCSV {
+		for target, source := range in.Mapping {
+			if !stringIn(columns, source) {
+				return nil, errors.Invalid("mapping."+target, errors.MsgColumnNotFound, "column", source)
+			}
+		}
+	}
+	imp := models.PlaceImport{
+		UserID:        userID,
+		PlaceTypeID:   pt.ID,
+		Format:        in.Format,
+		Mapping:       models.ImportMappingJSON(in.Mapping),
+		DryRun:        in.DryRun,
+		SkipDuplicate: in.SkipDuplicateCheck,
+		Status:        models.ImportStatusPending,
+		Payload:       in.Data,
+		TotalRows:     len(records),
+		Errors:        models.ImportErrorsJSON{},
+	}
+	if err := s.db.WithContext(ctx).Create(&imp).Error; err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+// Get returns one of the user's imports with its progress and error report.
+func (s *PlaceImportService) Get(ctx context.Context, userID, importID uuid.UUID) (*models.PlaceImport, error) {
+	var imp models.PlaceImport
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", importID, userID).First(&imp).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrImportNotFound
+		}
+		return nil, err
+	}
+	return &imp, nil
+}
+
+// List returns the user's imports, newest first.
+func (s *PlaceImportService) List(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.PlaceImport, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.PlaceImport{}).Where("user_id = ?", userID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.PlaceImport
+	err := q.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// Run processes a pending import. Every row is created on its own (one bad row does not stop the
+// import); failures go to the error report. Progress is saved every importProgressEvery rows.
+func (s *PlaceImportService) Run(ctx context.Context, importID uuid.UUID) error {
+	res := s.db.WithContext(ctx).Model(&models.PlaceImport{}).
+		Where("id = ? AND status = ?", importID, models.ImportStatusPending).
+		Update("status", models.ImportStatusRunning)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+	var imp models.PlaceImport
+	if err := s.db.WithContext(ctx).Where("id = ?", importID).First(&imp).Error; err != nil {
+		return err
+	}
+	var pt models.PlaceType
+	if err := s.db.WithContext(ctx).Where("id = ?", imp.PlaceTypeID).First(&pt).Error; err != nil {
+		return s.fail(ctx, &imp, err)
+	}
+	records, columns, err := parseImport(imp.Format, imp.Payload)
+	if err != nil {
+		return s.fail(ctx, &imp, err)
+	}
+	fields := FormFields(pt.FormSchema)
+	mapping := map[string]string(imp.Mapping)
+	if len(mapping) == 0 {
+		mapping = defaultImportMapping(columns, fields)
+	}
+	report := models.ImportErrorsJSON{}
+	succeeded, failed := 0, 0
+	for i, rec := range records {
+		if err := s.importRecord(ctx, &imp, pt.ID, fields, mapping, rec); err != nil {
+			failed++
+			if len(report) < maxImportErrors {
+				report = append(report, models.ImportRowError{Row: rec.Row, Error: err.Error()})
+			}
+		} else {
+			succeeded++
+		}
+		if (i+1)%importProgressEvery == 0 {
+			if err := s.db.WithContext(ctx).Model(&imp).Updates(map[string]interface{}{
+				"processed_rows": i + 1,
+				"succeeded_rows": succeeded,
+				"failed_rows":    failed,
+				"errors":         report,
+			}).Error; err != nil {
+				log.Printf("place import %s: save progress: %v", imp.ID, err)
+			}
+		}
+	}
+	now := time.Now()
+	return s.db.WithContext(ctx).Model(&imp).Updates(map[string]interface{}{
+		"status":         models.ImportStatusCompleted,
+		"total_rows":     len(records),
+		"processed_rows": len(records),
+		"succeeded_rows": succeeded,
+		"failed_rows":    failed,
+		"errors":         report,
+		"payload":        nil,
+		"completed_at":   now,
+	}).Error
+}
+
+// failRunning marks a running import failed with reason and drops its payload.
+func (s *PlaceImportService) failRunning(ctx context.Context, importID uuid.UUID, reason string) error {
+	return s.db.WithContext(ctx).Model(&models.PlaceImport{}).
+		Where("id = ? AND status = ?", importID, models.ImportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       models.ImportStatusFailed,
+			"error":        reason,
+			"payload":      nil,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+func (s *PlaceImportService) fail(ctx context.Context, imp *models.PlaceImport, cause error) error {
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(imp).Updates(map[string]interface{}{
+		"status":       models.ImportStatusFailed,
+		"error":        cause.Error(),
+		"payload":      nil,
+		"completed_at": now,
+	}).Error; err != nil {
+		return err
+	}
+	return cause
+}
+
+// importRecord validates one record and, unless the import is a dry run, creates the place.
+func (s *PlaceImportService) importRecord(ctx context.Context, imp *models.PlaceImport, placeTypeID uuid.UUID, fields []FormField, mapping map[string]string, rec importRecord) error {
+	if rec.Err != "" {
+		return fmt.Errorf("%s", rec.Err)
+	}
+	place, err := buildImportPlace(rec, mapping, fields)
+	if err != nil {
+		return err
+	}
+	place.PlaceTypeID = placeTypeID
+	var hours *models.PlaceOpeningHours
+	if in, ok := importOpeningHours(rec, mapping); ok {
+		if hours, err = s.hours.Normalize(in); err != nil {
+			return err
+		}
+	}
+	if !imp.SkipDuplicate {
+		candidates, err := s.dups.Candidates(ctx, place.Name, place.Name_local, place.Latitude, place.Longitude)
+		if err != nil {
+			return err
+		}
+		if len(candidates) > 0 {
+			return fmt.Errorf("possible duplicate of place %s (%s)", candidates[0].Place.ID, candidates[0].Place.Name)
+		}
+	}
+	if imp.DryRun {
+		return nil
+	}
+	if hours == nil {
+		return s.places.Create(ctx, &imp.UserID, place)
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := NewPlaceService(tx).Create(ctx, &imp.UserID, place); err != nil {
+			return err
+		}
+		hours.PlaceID = place.ID
+		return setOpeningHours(tx, &imp.UserID, hours)
+	})
+}
+
+// validateImportTarget checks a mapping target: a place field, opening_hours / timezone, or
+// details.<key> (a form field when the place type declares any).
+func validateImportTarget(target string, fields []FormField) error {
+	if target == "opening_hours" || target == "timezone" {
+		return nil
+	}
+	if key, ok := strings.CutPrefix(target, "details."); ok {
+		if key == "" {
+			return errors.Invalid("mapping", errors.MsgUnknown, "value", target)
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		for _, f := range fields {
+			if f.Key == key {
+				return nil
+			}
+		}
+		return errors.Invalid("mapping."+target, errors.MsgNotInSchema)
+	}
+	if target == "details" || !editablePlaceFields[target] {
+		return errors.Invalid("mapping", errors.MsgUnknown, "value", target)
+	}
+	return nil
+}
+
+// defaultImportMapping maps columns named like place fields to those fields and the remaining
+// columns to details keys (only form fields when the place type declares any).
+func defaultImportMapping(columns []string, fields []FormField) map[string]string {
+	mapping := map[string]string{}
+	for _, col := range columns {
+		if field, ok := importFieldAliases[strings.ToLower(strings.TrimSpace(col))]; ok {
+			if _, taken := mapping[field]; !taken {
+				mapping[field] = col
+			}
+			continue
+		}
+		// Exports name details keys that clash with a place field "details.<key>".
+		target := "details." + col
+		if strings.HasPrefix(col, "details.") {
+			target = col
+		}
+		if validateImportTarget(target, fields) == nil {
+			mapping[target] = col
+		}
+	}
+	return mapping
+}
+
+// buildImportPlace turns a record into a place according to the mapping and validates it.
+func buildImportPlace(rec importRecord, mapping map[string]string, fields []FormField) (*models.Place, error) {
+	place := &models.Place{}
+	details := map[string]interface{}{}
+	var lat, lon *float64
+	for target, source := range mapping {
+		v, ok := rec.Values[source]
+		if !ok || v == nil {
+			continue
+		}
+		if str, isString := v.(string); isString && strings.TrimSpace(str) == "" {
+			continue
+		}
+		if key, ok := strings.CutPrefix(target, "details."); ok {
+			details[key] = v
+			continue
+		}
+		if target == "opening_hours" || target == "timezone" {
+			continue // see importOpeningHours
+		}
+		if target == "latitude" || target == "longitude" {
+			f, err := importFloat(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number", target)
+			}
+			if target == "latitude" {
+				lat = &f
+			} else {
+				lon = &f
+			}
+			continue
+		}
+		str := strings.TrimSpace(fmt.Sprint(v))
+		switch target {
+		case "name":
+			place.Name = str
+		case "name_local":
+			place.Name_local = str
+		case "description":
+			place.Description = str
+		case "address":
+			place.Address = str
+		}
+	}
+	if rec.Lat != nil && rec.Lon != nil {
+		lat, lon = rec.Lat, rec.Lon
+	}
+	if place.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if lat == nil || lon == nil {
+		return nil, fmt.Errorf("latitude and longitude are required")
+	}
+	if *lat < -90 || *lat > 90 || *lon < -180 || *lon > 180 {
+		return nil, fmt.Errorf("coordinates out of range")
+	}
+	place.Latitude, place.Longitude = *lat, *lon
+	if err := validateDetails(fields, details); err != nil {
+		return nil, err
+	}
+	place.Details = models.DetailsJSON(details)
+	return place, nil
+}
+
+// importOpeningHours returns the record's opening hours (OSM syntax) when the mapping has them.
+func importOpeningHours(rec importRecord, mapping map[string]string) (OpeningHoursInput, bool) {
+	str := func(target string) string {
+		if v, ok := rec.Values[mapping[target]]; ok && v != nil {
+			return strings.TrimSpace(fmt.Sprint(v))
+		}
+		return ""
+	}
+	if mapping["opening_hours"] == "" {
+		return OpeningHoursInput{}, false
+	}
+	in := OpeningHoursInput{OSM: str("opening_hours"), Timezone: str("timezone")}
+	return in, in.OSM != ""
+}
+
+func importFloat(v interface{}) (float64, error) {
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(x), 64)
+	}
+	return 0, fmt.Errorf("not a number")
+}
+
+// parseImport parses the file into records and returns the source columns (CSV header, or the
+// union of GeoJSON property names).
+func parseImport(format string, data []byte) ([]importRecord, []string, error) {
+	if format == models.ImportFormatGeoJSON {
+		return parseGeoJSONImport(data)
+	}
+	return parseCSVImport(data)
+}
+
+func parseCSVImport(data []byte) ([]importRecord, []string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read CSV header: %v", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	var records []importRecord
+	for row := 1; ; row++ {
+		line, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSV row %d: %v", row, err)
+		}
+		values := make(map[string]interface{}, len(header))
+		for i, col := range header {
+			if i < len(line) {
+				values[col] = line[i]
+			}
+		}
+		records = append(records, importRecord{Row: row, Values: values})
+	}
+	return records, header, nil
+}
+
+func parseGeoJSONImport(data []byte) ([]importRecord, []string, error) {
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry *struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, nil, fmt.Errorf("invalid GeoJSON: %v", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, nil, fmt.Errorf("GeoJSON must be a FeatureCollection")
+	}
+	var columns []string
+	seen := map[string]bool{}
+	records := make([]importRecord, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		rec := importRecord{Row: i + 1, Values: f.Properties}
+		if rec.Values == nil {
+			rec.Values = map[string]interface{}{}
+		}
+		keys := make([]string, 0, len(f.Properties))
+		for k := range f.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+		if f.Geometry != nil {
+			var coords []float64
+			if f.Geometry.Type != "Point" || json.Unmarshal(f.Geometry.Coordinates, &coords) != nil || len(coords) < 2 {
+				rec.Err = "geometry must be a Point"
+			} else {
+				rec.Lon, rec.Lat = &coords[0], &coords[1]
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, columns, nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlaceRevisionService lists place revisions with field-level diffs and restores old revisions.
+type PlaceRevisionService struct {
+	db *gorm.DB
+}
+
+// NewPlaceRevisionService returns a PlaceRevisionService.
+func NewPlaceRevisionService(db *gorm.DB) *PlaceRevisionService {
+	return &PlaceRevisionService{db: db}
+}
+
+// FieldDiff is one changed field between two revisions. Detail keys appear as "details.<key>".
+type FieldDiff struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// RevisionView is a revision with its diff against the previous revision.
+type RevisionView struct {
+	models.PlaceRevision
+	Changes []FieldDiff `json:"changes"`
+}
+
+// List returns the place's revisions, newest first, each with its diff to the one before.
+func (s *PlaceRevisionService) List(ctx context.Context, placeID uuid.UUID) ([]RevisionView, error) {
+	var revs []models.PlaceRevision
+	if err := s.db.WithContext(ctx).Where("place_id = ?", placeID).Order("number ASC").Find(&revs).Error; err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		var n int64
+		if err := s.db.WithContext(ctx).Unscoped().Model(&models.Place{}).Where("id = ?", placeID).Count(&n).Error; err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, errors.ErrPlaceNotFound
+		}
+	}
+	out := make([]RevisionView, len(revs))
+	var prev map[string]interface{}
+	for i, r := range revs {
+		out[len(revs)-1-i] = RevisionView{PlaceRevision: r, Changes: diffSnapshots(prev, r.Snapshot)}
+		prev = r.Snapshot
+	}
+	return out, nil
+}
+
+// Restore writes the content of revision number back to the place (undeleting it if needed) and
+// records a restore revision.
+func (s *PlaceRevisionService) Restore(ctx context.Context, actorID, placeID uuid.UUID, number int) (*models.Place, error) {
+	var place models.Place
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rev models.PlaceRevision
+		if err := tx.Where("place_id = ? AND number = ?", placeID, number).First(&rev).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRevisionNotFound
+			}
+			return err
+		}
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		to, err := placeRedirect(tx, placeID)
+		if err != nil {
+			return err
+		}
+		if to != nil {
+			return errors.Msg(errors.ErrConflict, errors.MsgPlaceMerged, "place_id", to.String())
+		}
+		if err := ensureBaselineRevision(tx, &place); err != nil {
+			return err
+		}
+		// The snapshot replaces the place content wholesale, including details keys added since.
+		details := models.DetailsJSON{}
+		if d, ok := rev.Snapshot["details"].(map[string]interface{}); ok {
+			for k, v := range d {
+				details[k] = v
+			}
+		}
+		updates := map[string]interface{}{"details": details, "deleted_at": nil}
+		for field := range editablePlaceFields {
+			if v, ok := rev.Snapshot[field]; ok && field != "details" {
+				updates[field] = v
+			}
+		}
+		if ptID, ok := rev.Snapshot["place_type_id"].(string); ok {
+			updates["place_type_id"] = ptID
+		}
+		before := place
+		if err := tx.Unscoped().Model(&place).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := recordPlaceHistory(tx, placeID, &actorID, models.PlaceHistoryRestore, "", "", "revision "+strconv.Itoa(number)); err != nil {
+			return err
+		}
+		if err := recordPlaceRevision(tx, placeID, &actorID, models.RevisionRestore); err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", placeID).First(&place).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &before, &place)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &place, nil
+}
+
+// placeSnapshot returns the revisioned content of the place.
+func placeSnapshot(p *models.Place) models.PlaceChangesJSON {
+	details := map[string]interface{}{}
+	for k, v := range p.Details {
+		details[k] = v
+	}
+	snap := map[string]interface{}{
+		"name":          p.Name,
+		"name_local":    p.Name_local,
+		"description":   p.Description,
+		"address":       p.Address,
+		"latitude":      p.Latitude,
+		"longitude":     p.Longitude,
+		"details":       details,
+		"place_type_id": p.PlaceTypeID.String(),
+	}
+	return models.PlaceChangesJSON(normalizeJSON(snap).(map[string]interface{}))
+}
+
+// recordPlaceRevision snapshots the place (including soft-deleted) as the next revision.
+func recordPlaceRevision(tx *gorm.DB, placeID uuid.UUID, actorID *uuid.UUID, action string) error {
+	var place models.Place
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+		return err
+	}
+	var last int
+	if err := tx.Model(&models.PlaceRevision{}).Where("place_id = ?", placeID).
+		Select("COALESCE(MAX(number), 0)").Scan(&last).Error; err != nil {
+		return err
+	}
+	return tx.Create(&models.PlaceRevision{
+		PlaceID:  placeID,
+		Number:   last + 1,
+		AuthorID: actorID,
+		Action:   action,
+		Snapshot: placeSnapshot(&place),
+	}).Error
+}
+
+// ensureBaselineRevision records the place's current state as revision 1 when it has no
+// revisions yet (places created before revisions existed), so the first change has a diff base.
+func ensureBaselineRevision(tx *gorm.DB, place *models.Place) error {
+	var n int64
+	if err := tx.Model(&models.PlaceRevision{}).Where("place_id = ?", place.ID).Count(&n).Error; err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	return tx.Create(&models.PlaceRevision{
+		PlaceID:   place.ID,
+		Number:    1,
+		AuthorID:  place.OwnerID,
+		Action:    models.RevisionCreate,
+		Snapshot:  placeSnapshot(place),
+		CreatedAt: place.CreatedAt,
+	}).Error
+}
+
+// diffSnapshots compares two snapshots field by field; detail keys are compared individually.
+func diffSnapshots(prev, cur map[string]interface{}) []FieldDiff {
+	diffs := []FieldDiff{}
+	fields := map[string]bool{}
+	for k := range prev {
+		fields[k] = true
+	}
+	for k := range cur {
+		fields[k] = true
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, f := range names {
+		if f == "details" {
+			pd, _ := prev[f].(map[string]interface{})
+			cd, _ := cur[f].(map[string]interface{})
+			for _, d := range diffSnapshots(pd, cd) {
+				d.Field = "details." + d.Field
+				diffs = append(diffs, d)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(prev[f], cur[f]) {
+			diffs = append(diffs, FieldDiff{Field: f, From: prev[f], To: cur[f]})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// editablePlaceFields are the place fields an edit (suggestion, revision) may change, by JSON name.
+var editablePlaceFields = map[string]bool{
+	"name":        true,
+	"name_local":  true,
+	"description": true,
+	"address":     true,
+	"latitude":    true,
+	"longitude":   true,
+	"details":     true,
+}
+
+// PlaceService creates, updates and deletes places, recording each change in the place history
+// and as a revision.
+type PlaceService struct {
+	db *gorm.DB
+}
+
+// NewPlaceService returns a PlaceService.
+func NewPlaceService(db *gorm.DB) *PlaceService {
+	return &PlaceService{db: db}
+}
+
+// Create stores a new, unverified place. Verification goes through PlaceVerificationService.
+func (s *PlaceService) Create(ctx context.Context, actorID *uuid.UUID, place *models.Place) error {
+	if err := checkBannedWords("name", place.Name, "name_local", place.Name_local,
+		"description", place.Description, "address", place.Address); err != nil {
+		return err
+	}
+	place.IsVerified = false
+	place.VerificationStatus = models.PlaceUnverified
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(place).Error; err != nil {
+			return err
+		}
+		if err := recordPlaceHistory(tx, place.ID, actorID, models.PlaceHistoryCreate, "", models.PlaceUnverified, ""); err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, place)
+		return recordPlaceRevision(tx, place.ID, actorID, models.RevisionCreate)
+	})
+}
+
+// PlaceFilter holds the filters shared by the place listing and the place export.
+type PlaceFilter struct {
+	PlaceType          string     // slug or ID
+	Query              string     // case-insensitive substring of name or name_local
+	VerificationStatus string     // unverified | pending | verified | rejected
+	OwnerID            *uuid.UUID // places owned by this user
+	BBox               []float64  // min_lon, min_lat, max_lon, max_lat
+	OpenAt             *time.Time // places whose opening hours cover this instant
+}
+
+// List returns places matching the filter, newest first.
+func (s *PlaceService) List(ctx context.Context, f PlaceFilter, page, limit int) ([]models.Place, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q, err := s.filtered(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.Place
+	err = q.Preload("PlaceType").Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// filtered returns a query of the visible (not hidden by moderation) places with the filter applied.
+func (s *PlaceService) filtered(ctx context.Context, f PlaceFilter) (*gorm.DB, error) {
+	q := s.db.WithContext(ctx).Model(&models.Place{}).Where("hidden_at IS NULL")
+	if f.PlaceType != "" {
+		var pt models.PlaceType
+		lookup := s.db.WithContext(ctx).Where("slug = ?", f.PlaceType)
+		if id, err := uuid.Parse(f.PlaceType); err == nil {
+			lookup = s.db.WithContext(ctx).Where("id = ?", id)
+		}
+		if err := lookup.First(&pt).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.Invalid("place_type", errors.MsgUnknown, "value", f.PlaceType)
+			}
+			return nil, err
+		}
+		q = q.Where("place_type_id = ?", pt.ID)
+	}
+	if term := strings.TrimSpace(f.Query); term != "" {
+		like := "%" + strings.ToLower(term) + "%"
+		q = q.Where("LOWER(name) LIKE ? OR LOWER(name_local) LIKE ?", like, like)
+	}
+	switch f.VerificationStatus {
+	case "":
+	case models.PlaceUnverified, models.PlacePending, models.PlaceVerified, models.PlaceRejected:
+		q = q.Where("verification_status = ?", f.VerificationStatus)
+	default:
+		return nil, errors.Invalid("verification_status", errors.MsgOneOf, "values", "unverified, pending, verified, rejected")
+	}
+	if f.OwnerID != nil {
+		q = q.Where("owner_id = ?", *f.OwnerID)
+	}
+	if f.BBox != nil {
+		if len(f.BBox) != 4 || f.BBox[0] > f.BBox[2] || f.BBox[1] > f.BBox[3] {
+			return nil, errors.Invalid("bbox", errors.MsgFormat, "format", "min_lon,min_lat,max_lon,max_lat")
+		}
+		q = q.Where("longitude BETWEEN ? AND ? AND latitude BETWEEN ? AND ?", f.BBox[0], f.BBox[2], f.BBox[1], f.BBox[3])
+	}
+	if f.OpenAt != nil {
+		q = q.Where(openAtCondition, f.OpenAt.UTC())
+	}
+	return q, nil
+}
+
+// Get returns the place unless it is hidden by moderation. For a place that was merged away it
+// returns ErrPlaceNotFound together with the ID of the place it was merged into.
+func (s *PlaceService) Get(ctx context.Context, placeID uuid.UUID) (*models.Place, *uuid.UUID, error) {
+	var place models.Place
+	err := s.db.WithContext(ctx).Preload("PlaceType").Where("id = ? AND hidden_at IS NULL", placeID).First(&place).Error
+	if err == nil {
+		return &place, nil, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, nil, err
+	}
+	to, err := placeRedirect(s.db.WithContext(ctx), placeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, to, errors.ErrPlaceNotFound
+}
+
+// Update applies changes (same shape as an edit suggestion) to the place.
+func (s *PlaceService) Update(ctx context.Context, actorID, placeID uuid.UUID, changes map[string]interface{}) (*models.Place, error) {
+	if err := validatePlaceChanges(changes); err != nil {
+		return nil, err
+	}
+	var place models.Place
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if err := applyPlaceChanges(tx, &place, changes, &actorID, ""); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", placeID).First(&place).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &place, nil
+}
+
+// Delete soft-deletes the place. It can be brought back by restoring one of its revisions.
+func (s *PlaceService) Delete(ctx context.Context, actorID, placeID uuid.UUID) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if err := ensureBaselineRevision(tx, &place); err != nil {
+			return err
+		}
+		if err := tx.Delete(&place).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &place)
+		if err := recordPlaceHistory(tx, placeID, &actorID, models.PlaceHistoryDelete, "", "", ""); err != nil {
+			return err
+		}
+		return recordPlaceRevision(tx, placeID, &actorID, models.RevisionDelete)
+	})
+}
+
+// validatePlaceChanges checks that changes only touch editable fields with values of the right type.
+func validatePlaceChanges(changes map[string]interface{}) error {
+	if len(changes) == 0 {
+		return errors.Invalid("changes", errors.MsgNotEmpty)
+	}
+	for field, v := range changes {
+		if !editablePlaceFields[field] {
+			return errors.Invalid(field, errors.MsgNotEditable)
+		}
+		switch field {
+		case "latitude", "longitude":
+			f, ok := v.(float64)
+			limit := 90.0
+			if field == "longitude" {
+				limit = 180
+			}
+			if !ok || f < -limit || f > limit {
+				return errors.Invalid(field, errors.MsgRange, "min", strconv.FormatFloat(-limit, 'g', -1, 64), "max", strconv.FormatFloat(limit, 'g', -1, 64))
+			}
+		case "details":
+			d, ok := v.(map[string]interface{})
+			if !ok || len(d) == 0 {
+				return errors.Invalid("details", errors.MsgObject)
+			}
+			for k, dv := range d {
+				if str, ok := dv.(string); ok {
+					if err := checkBannedWords("details."+k, str); err != nil {
+						return err
+					}
+				}
+			}
+		case "name":
+			str, ok := v.(string)
+			if !ok || strings.TrimSpace(str) == "" {
+				return errors.Invalid("name", errors.MsgRequired)
+			}
+			if err := checkBannedWords(field, str); err != nil {
+				return err
+			}
+		default:
+			str, ok := v.(string)
+			if !ok {
+				return errors.Invalid(field, errors.MsgString)
+			}
+			if err := checkBannedWords(field, str); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// placeValues returns the current values of the fields named in changes, in the same JSON shape.
+// For details only the keys named in changes["details"] are included (missing keys as nil).
+func placeValues(p *models.Place, changes map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(changes))
+	for field, v := range changes {
+		switch field {
+		case "name":
+			out[field] = p.Name
+		case "name_local":
+			out[field] = p.Name_local
+		case "description":
+			out[field] = p.Description
+		case "address":
+			out[field] = p.Address
+		case "latitude":
+			out[field] = p.Latitude
+		case "longitude":
+			out[field] = p.Longitude
+		case "details":
+			keys, _ := v.(map[string]interface{})
+			d := make(map[string]interface{}, len(keys))
+			for k := range keys {
+				d[k] = p.Details[k]
+			}
+			out[field] = d
+		}
+	}
+	return normalizeJSON(out).(map[string]interface{})
+}
+
+// placeConflicts lists the fields whose current value differs from base.
+func placeConflicts(p *models.Place, base map[string]interface{}) []string {
+	current := placeValues(p, base)
+	base = normalizeJSON(base).(map[string]interface{})
+	var fields []string
+	for field := range base {
+		if !reflect.DeepEqual(current[field], base[field]) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// applyPlaceChanges writes changes to the place inside tx and records an edit history entry and
+// a revision. Detail keys with a nil value are removed.
+func applyPlaceChanges(tx *gorm.DB, place *models.Place, changes map[string]interface{}, actorID *uuid.UUID, notes string) error {
+	updates := map[string]interface{}{}
+	fields := make([]string, 0, len(changes))
+	for field, v := range changes {
+		fields = append(fields, field)
+		if field != "details" {
+			updates[field] = v
+			continue
+		}
+		details := models.DetailsJSON{}
+		for k, dv := range place.Details {
+			details[k] = dv
+		}
+		for k, dv := range v.(map[string]interface{}) {
+			if dv == nil {
+				delete(details, k)
+			} else {
+				details[k] = dv
+			}
+		}
+		updates["details"] = details
+	}
+	if err := ensureBaselineRevision(tx, place); err != nil {
+		return err
+	}
+	before, after := *place, *place
+	if v, ok := changes["latitude"].(float64); ok {
+		after.Latitude = v
+	}
+	if v, ok := changes["longitude"].(float64); ok {
+		after.Longitude = v
+	}
+	if err := tx.Model(place).Updates(updates).Error; err != nil {
+		return err
+	}
+	invalidatePlaceTiles(tx, &before, &after)
+	sort.Strings(fields)
+	if notes != "" {
+		notes = ": " + notes
+	}
+	if err := recordPlaceHistory(tx, place.ID, actorID, models.PlaceHistoryEdit, "", "", strings.Join(fields, ", ")+notes); err != nil {
+		return err
+	}
+	return recordPlaceRevision(tx, place.ID, actorID, models.RevisionUpdate)
+}
+
+// normalizeJSON round-trips v through JSON so values compare equal regardless of Go type.
+func normalizeJSON(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return v
+	}
+	return out
+}
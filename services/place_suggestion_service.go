@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reputationPerAcceptedSuggestion is the reputation a contributor earns per accepted suggestion.
+const reputationPerAcceptedSuggestion = 10
+
+// PlaceSuggestionService handles community edit suggestions and the contributor counters.
+type PlaceSuggestionService struct {
+	db *gorm.DB
+}
+
+// NewPlaceSuggestionService returns a PlaceSuggestionService.
+func NewPlaceSuggestionService(db *gorm.DB) *PlaceSuggestionService {
+	return &PlaceSuggestionService{db: db}
+}
+
+// SuggestionInput is the body of POST /api/places/:id/suggestions.
+type SuggestionInput struct {
+	Changes map[string]interface{} `json:"changes"`
+	Comment string                 `json:"comment"`
+}
+
+// Suggest records a proposed diff to the place along with the current values of the touched fields.
+func (s *PlaceSuggestionService) Suggest(ctx context.Context, authorID, placeID uuid.UUID, in SuggestionInput) (*models.PlaceEditSuggestion, error) {
+	if err := validatePlaceChanges(in.Changes); err != nil {
+		return nil, err
+	}
+	if err := checkBannedWords("comment", in.Comment); err != nil {
+		return nil, err
+	}
+	var sug models.PlaceEditSuggestion
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		base := placeValues(&place, in.Changes)
+		if len(placeConflicts(&place, in.Changes)) == 0 {
+			return errors.Invalid("changes", errors.MsgNoChanges)
+		}
+		sug = models.PlaceEditSuggestion{
+			PlaceID:  placeID,
+			AuthorID: authorID,
+			Changes:  models.PlaceChangesJSON(in.Changes),
+			Base:     models.PlaceChangesJSON(base),
+			Comment:  strings.TrimSpace(in.Comment),
+			Status:   models.SuggestionPending,
+		}
+		if err := tx.Create(&sug).Error; err != nil {
+			return err
+		}
+		return bumpContributor(tx, authorID, "submitted", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sug, nil
+}
+
+// List returns suggestions, optionally for one place and / or status, oldest first.
+func (s *PlaceSuggestionService) List(ctx context.Context, placeID *uuid.UUID, status string, page, limit int) ([]models.PlaceEditSuggestion, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.PlaceEditSuggestion{})
+	if placeID != nil {
+		q = q.Where("place_id = ?", *placeID)
+	}
+	switch status {
+	case "":
+	case models.SuggestionPending, models.SuggestionAccepted, models.SuggestionRejected:
+		q = q.Where("status = ?", status)
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "pending, accepted, rejected")
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.PlaceEditSuggestion
+	err := q.Order("created_at ASC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// Accept applies the suggestion to the place atomically. If any touched field changed since the
+// suggestion was made, it fails with ErrConflict naming the fields and the suggestion stays pending.
+func (s *PlaceSuggestionService) Accept(ctx context.Context, reviewerID, placeID, suggestionID uuid.UUID, notes string) (*models.PlaceEditSuggestion, error) {
+	notes = strings.TrimSpace(notes)
+	var sug models.PlaceEditSuggestion
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.loadPending(tx, placeID, suggestionID, reviewerID, &sug); err != nil {
+			return err
+		}
+		var place models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if conflicts := placeConflicts(&place, sug.Base); len(conflicts) > 0 {
+			return errors.Msg(errors.ErrConflict, errors.MsgPlaceChanged, "fields", strings.Join(conflicts, ", "))
+		}
+		if err := applyPlaceChanges(tx, &place, sug.Changes, &reviewerID, "suggestion "+sug.ID.String()); err != nil {
+			return err
+		}
+		if err := s.review(tx, &sug, reviewerID, models.SuggestionAccepted, notes); err != nil {
+			return err
+		}
+		return bumpContributor(tx, sug.AuthorID, "accepted", reputationPerAcceptedSuggestion)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sug, nil
+}
+
+// Reject declines the suggestion; notes are required.
+func (s *PlaceSuggestionService) Reject(ctx context.Context, reviewerID, placeID, suggestionID uuid.UUID, notes string) (*models.PlaceEditSuggestion, error) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return nil, errors.Invalid("notes", errors.MsgRequiredToReject)
+	}
+	var sug models.PlaceEditSuggestion
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.loadPending(tx, placeID, suggestionID, reviewerID, &sug); err != nil {
+			return err
+		}
+		if err := s.review(tx, &sug, reviewerID, models.SuggestionRejected, notes); err != nil {
+			return err
+		}
+		return bumpContributor(tx, sug.AuthorID, "rejected", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sug, nil
+}
+
+// Contributor returns the user's counters (zero values if they never suggested anything).
+func (s *PlaceSuggestionService) Contributor(ctx context.Context, userID uuid.UUID) (*models.ContributorStats, error) {
+	stats := models.ContributorStats{UserID: userID}
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&stats).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// TopContributors returns the contributors with the highest reputation.
+func (s *PlaceSuggestionService) TopContributors(ctx context.Context, limit int) ([]models.ContributorStats, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	var list []models.ContributorStats
+	err := s.db.WithContext(ctx).Where("reputation > 0").
+		Order("reputation DESC, accepted DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (s *PlaceSuggestionService) loadPending(tx *gorm.DB, placeID, suggestionID, reviewerID uuid.UUID, sug *models.PlaceEditSuggestion) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND place_id = ?", suggestionID, placeID).First(sug).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrRequestNotFound
+		}
+		return err
+	}
+	if sug.Status != models.SuggestionPending {
+		return errors.ErrRequestNotPending
+	}
+	if sug.AuthorID == reviewerID {
+		return errors.ErrSelfReview
+	}
+	return nil
+}
+
+func (s *PlaceSuggestionService) review(tx *gorm.DB, sug *models.PlaceEditSuggestion, reviewerID uuid.UUID, status, notes string) error {
+	now := time.Now()
+	sug.Status, sug.ReviewerID, sug.ReviewNotes, sug.ReviewedAt = status, &reviewerID, notes, &now
+	sug.ReviewerImpersonatorID = models.ImpersonatorFrom(tx)
+	return tx.Model(sug).Updates(map[string]interface{}{
+		"status":                   status,
+		"reviewer_id":              reviewerID,
+		"review_notes":             notes,
+		"reviewed_at":              now,
+		"reviewer_impersonator_id": sug.ReviewerImpersonatorID,
+	}).Error
+}
+
+// bumpContributor increments one counter column (submitted, accepted, rejected) and adds reputation.
+func bumpContributor(tx *gorm.DB, userID uuid.UUID, column string, reputation int) error {
+	return tx.Exec(
+		`INSERT INTO contributor_stats (user_id, `+column+`, reputation, updated_at) VALUES (?, 1, ?, NOW())
+		 ON CONFLICT (user_id) DO UPDATE SET `+column+` = contributor_stats.`+column+` + 1,
+		 reputation = contributor_stats.reputation + EXCLUDED.reputation, updated_at = NOW()`,
+		userID, reputation,
+	).Error
+}
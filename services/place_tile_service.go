@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxTileZoom is the deepest zoom level served.
+	MaxTileZoom = 22
+	// placeTileChannel is the Postgres NOTIFY channel for tile invalidation. Payload is a list of
+	// "lat,lon" points separated by ";": every cached tile containing one of them is dropped.
+	placeTileChannel = "place_tiles"
+
+	tileExtent              = 4096
+	tileBuffer              = 64
+	clusterGridCells        = 64 // cluster grid cells per tile side
+	webMercatorHalf         = 20037508.342789244
+	defaultTileCacheTTL     = 10 * time.Minute
+	defaultTileCacheEntries = 10000
+	defaultClusterMaxZoom   = 12
+)
+
+// tileXYZ identifies a tile.
+type tileXYZ struct {
+	z, x, y int
+}
+
+type cachedTile struct {
+	data    []byte
+	expires time.Time
+}
+
+// TileCache keeps generated tiles per tile and filter for a TTL. Safe for concurrent use.
+type TileCache struct {
+	mu         sync.Mutex
+	entries    map[tileXYZ]map[string]cachedTile
+	size       int
+	ttl        time.Duration
+	maxEntries int
+}
+
+// defaultTileCache is shared by every PlaceTileService in the process so that invalidation from
+// place writes (and the NOTIFY listener) reaches it.
+var defaultTileCache = newTileCacheFromEnv()
+
+// newTileCacheFromEnv reads PLACE_TILE_CACHE_TTL (default 10m, 0 disables caching) and
+// PLACE_TILE_CACHE_ENTRIES (default 10000).
+func newTileCacheFromEnv() *TileCache {
+	c := &TileCache{entries: map[tileXYZ]map[string]cachedTile{}, ttl: defaultTileCacheTTL, maxEntries: defaultTileCacheEntries}
+	if d, err := time.ParseDuration(os.Getenv("PLACE_TILE_CACHE_TTL")); err == nil && d >= 0 {
+		c.ttl = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("PLACE_TILE_CACHE_ENTRIES")); err == nil && n > 0 {
+		c.maxEntries = n
+	}
+	return c
+}
+
+func (c *TileCache) get(t tileXYZ, filter string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[t][filter]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.data, true
+}
+
+func (c *TileCache) put(t tileXYZ, filter string, data []byte) {
+	if c.ttl == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size >= c.maxEntries {
+		// Full: drop everything rather than track recency; tiles are cheap to regenerate.
+		c.entries, c.size = map[tileXYZ]map[string]cachedTile{}, 0
+	}
+	if c.entries[t] == nil {
+		c.entries[t] = map[string]cachedTile{}
+	}
+	if _, ok := c.entries[t][filter]; !ok {
+		c.size++
+	}
+	c.entries[t][filter] = cachedTile{data: data, expires: time.Now().Add(c.ttl)}
+}
+
+// InvalidatePoint drops every cached tile (all zoom levels and filters) containing the point,
+// and its neighbours, whose render buffer may include it.
+func (c *TileCache) InvalidatePoint(lat, lon float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for z := 0; z <= MaxTileZoom; z++ {
+		center := tileForPoint(z, lat, lon)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				t := tileXYZ{z, center.x + dx, center.y + dy}
+				c.size -= len(c.entries[t])
+				delete(c.entries, t)
+			}
+		}
+	}
+}
+
+// InvalidateAll drops every cached tile.
+func (c *TileCache) InvalidateAll() {
+	c.mu.Lock()
+	c.entries, c.size = map[tileXYZ]map[string]cachedTile{}, 0
+	c.mu.Unlock()
+}
+
+// PlaceTileService renders places as Mapbox Vector Tiles with PostGIS ST_AsMVT. Below the
+// cluster zoom places are grouped on a grid; features then carry point_count.
+type PlaceTileService struct {
+	db             *gorm.DB
+	places         *PlaceService
+	cache          *TileCache
+	clusterMaxZoom int
+}
+
+// NewPlaceTileService returns a PlaceTileService. PLACE_TILE_CLUSTER_MAX_ZOOM (default 12) is the
+// deepest zoom level at which places are clustered.
+func NewPlaceTileService(db *gorm.DB) *PlaceTileService {
+	s := &PlaceTileService{db: db, places: NewPlaceService(db), cache: defaultTileCache, clusterMaxZoom: defaultClusterMaxZoom}
+	if n, err := strconv.Atoi(os.Getenv("PLACE_TILE_CLUSTER_MAX_ZOOM")); err == nil && n >= -1 {
+		s.clusterMaxZoom = n
+	}
+	return s
+}
+
+// Tile returns the encoded tile (empty when no place falls in it). Only the place type and
+// verification status filters apply.
+func (s *PlaceTileService) Tile(ctx context.Context, z, x, y int, f PlaceFilter) ([]byte, error) {
+	if z < 0 || z > MaxTileZoom || x < 0 || y < 0 || x >= 1<<z || y >= 1<<z {
+		return nil, errors.Invalid("tile", errors.MsgInvalidValue)
+	}
+	t := tileXYZ{z, x, y}
+	f = PlaceFilter{PlaceType: f.PlaceType, VerificationStatus: f.VerificationStatus}
+	key := f.PlaceType + "|" + f.VerificationStatus
+	if data, ok := s.cache.get(t, key); ok {
+		return data, nil
+	}
+
+	// Prefilter on the plain lat/lon columns with the tile bounds plus the render buffer.
+	minLon, minLat, maxLon, maxLat := tileBounds(z, x, y)
+	padLon := (maxLon - minLon) * tileBuffer / tileExtent
+	padLat := (maxLat - minLat) * tileBuffer / tileExtent
+	f.BBox = []float64{minLon - padLon, math.Max(minLat-padLat, -85.05112878), maxLon + padLon, math.Min(maxLat+padLat, 85.05112878)}
+	q, err := s.places.filtered(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	q = q.Select("places.id, places.name, places.name_local, places.verification_status, places.place_type_id, places.latitude, places.longitude")
+
+	size := 2 * webMercatorHalf / float64(int(1)<<z)
+	minX, maxY := -webMercatorHalf+float64(x)*size, webMercatorHalf-float64(y)*size
+	envelope := []interface{}{minX, maxY - size, minX + size, maxY}
+
+	var data []byte
+	if z <= s.clusterMaxZoom {
+		err = s.db.WithContext(ctx).Raw(`
+			WITH bounds AS (SELECT ST_MakeEnvelope(?, ?, ?, ?, 3857) AS geom),
+			pts AS (
+				SELECT f.id, f.name, f.verification_status, pt.slug AS place_type,
+				       ST_Transform(ST_SetSRID(ST_MakePoint(f.longitude, f.latitude), 4326), 3857) AS geom
+				FROM (?) f JOIN place_types pt ON pt.id = f.place_type_id
+			),
+			clusters AS (
+				SELECT ST_Centroid(ST_Collect(geom)) AS geom, COUNT(*) AS point_count,
+				       CASE WHEN COUNT(*) = 1 THEN (array_agg(id::text))[1] END AS id,
+				       CASE WHEN COUNT(*) = 1 THEN MIN(name) END AS name,
+				       CASE WHEN COUNT(*) = 1 THEN MIN(place_type) END AS place_type,
+				       CASE WHEN COUNT(*) = 1 THEN MIN(verification_status) END AS verification_status
+				FROM pts GROUP BY ST_SnapToGrid(geom, ?)
+			),
+			mvt AS (
+				SELECT point_count, id, name, place_type, verification_status,
+				       ST_AsMVTGeom(clusters.geom, bounds.geom, ?, ?, true) AS geom
+				FROM clusters, bounds
+			)
+			SELECT ST_AsMVT(mvt.*, 'places', ?, 'geom') FROM mvt WHERE geom IS NOT NULL`,
+			append(envelope, q, size/clusterGridCells, tileExtent, tileBuffer, tileExtent)...).Row().Scan(&data)
+	} else {
+		err = s.db.WithContext(ctx).Raw(`
+			WITH bounds AS (SELECT ST_MakeEnvelope(?, ?, ?, ?, 3857) AS geom),
+			mvt AS (
+				SELECT f.id::text AS id, f.name, f.name_local, f.verification_status, pt.slug AS place_type,
+				       ST_AsMVTGeom(ST_Transform(ST_SetSRID(ST_MakePoint(f.longitude, f.latitude), 4326), 3857),
+				                    bounds.geom, ?, ?, true) AS geom
+				FROM (?) f JOIN place_types pt ON pt.id = f.place_type_id, bounds
+			)
+			SELECT ST_AsMVT(mvt.*, 'places', ?, 'geom') FROM mvt WHERE geom IS NOT NULL`,
+			append(envelope, tileExtent, tileBuffer, q, tileExtent)...).Row().Scan(&data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(t, key, data)
+	return data, nil
+}
+
+// invalidatePlaceTiles drops cached tiles containing the places' positions, locally and on other
+// instances. When db is a transaction the NOTIFY is delivered on commit, and this instance's
+// listener drops the tiles again then, covering renders that raced the commit.
+func invalidatePlaceTiles(db *gorm.DB, places ...*models.Place) {
+	points := make([]string, 0, len(places))
+	for _, p := range places {
+		defaultTileCache.InvalidatePoint(p.Latitude, p.Longitude)
+		points = append(points, strconv.FormatFloat(p.Latitude, 'f', -1, 64)+","+strconv.FormatFloat(p.Longitude, 'f', -1, 64))
+	}
+	if err := db.Exec("SELECT pg_notify(?, ?)", placeTileChannel, strings.Join(points, ";")).Error; err != nil {
+		log.Printf("tile cache: notify failed: %v", err)
+	}
+}
+
+// ListenPlaceTileInvalidation LISTENs for tile invalidations from place writes (this and other
+// instances) until ctx is done. On reconnect the whole tile cache is dropped.
+func ListenPlaceTileInvalidation(ctx context.Context, dsn string) {
+	listenNotifications(ctx, dsn, placeTileChannel, defaultTileCache.InvalidateAll, func(payload string) {
+		for _, point := range strings.Split(payload, ";") {
+			latStr, lonStr, ok := strings.Cut(point, ",")
+			if !ok {
+				continue
+			}
+			lat, err1 := strconv.ParseFloat(latStr, 64)
+			lon, err2 := strconv.ParseFloat(lonStr, 64)
+			if err1 == nil && err2 == nil {
+				defaultTileCache.InvalidatePoint(lat, lon)
+			}
+		}
+	})
+}
+
+// tileBounds returns the WGS84 bounds (min lon, min lat, max lon, max lat) of a tile.
+func tileBounds(z, x, y int) (float64, float64, float64, float64) {
+	n := float64(int(1) << z)
+	lon := func(x float64) float64 { return x/n*360 - 180 }
+	lat := func(y float64) float64 { return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi }
+	return lon(float64(x)), lat(float64(y + 1)), lon(float64(x + 1)), lat(float64(y))
+}
+
+// tileForPoint returns the tile containing the point at zoom z.
+func tileForPoint(z int, lat, lon float64) tileXYZ {
+	n := int(1) << z
+	lat = math.Max(math.Min(lat, 85.05112878), -85.05112878)
+	x := int((lon + 180) / 360 * float64(n))
+	rad := lat * math.Pi / 180
+	y := int((1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * float64(n))
+	clamp := func(v int) int { return int(math.Max(0, math.Min(float64(n-1), float64(v)))) }
+	return tileXYZ{z, clamp(x), clamp(y)}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceTypeService reads place types.
+type PlaceTypeService struct {
+	db *gorm.DB
+}
+
+// NewPlaceTypeService returns a PlaceTypeService.
+func NewPlaceTypeService(db *gorm.DB) *PlaceTypeService {
+	return &PlaceTypeService{db: db}
+}
+
+// List returns every place type ordered by slug.
+func (s *PlaceTypeService) List(ctx context.Context) ([]models.PlaceType, error) {
+	var types []models.PlaceType
+	if err := s.db.WithContext(ctx).Order("slug").Find(&types).Error; err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// Get returns the place type.
+func (s *PlaceTypeService) Get(ctx context.Context, id uuid.UUID) (*models.PlaceType, error) {
+	var pt models.PlaceType
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&pt).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPlaceTypeNotFound
+		}
+		return nil, err
+	}
+	return &pt, nil
+}
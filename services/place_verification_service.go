@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxEvidenceURLs = 10
+
+// PlaceVerificationService runs the place verification workflow: a request with evidence moves the
+// place to pending, and a reviewer with places:verify approves (verified) or rejects it.
+type PlaceVerificationService struct {
+	db *gorm.DB
+}
+
+// NewPlaceVerificationService returns a PlaceVerificationService.
+func NewPlaceVerificationService(db *gorm.DB) *PlaceVerificationService {
+	return &PlaceVerificationService{db: db}
+}
+
+// VerificationInput is the body of POST /api/places/:id/verification.
+type VerificationInput struct {
+	Evidence     string   `json:"evidence"`
+	EvidenceURLs []string `json:"evidence_urls"`
+}
+
+// Request opens a verification request for the place. Fails with ErrConflict if the place is
+// already verified or has a pending request.
+func (s *PlaceVerificationService) Request(ctx context.Context, actorID, placeID uuid.UUID, in VerificationInput) (*models.PlaceVerificationRequest, error) {
+	in.Evidence = strings.TrimSpace(in.Evidence)
+	if in.Evidence == "" && len(in.EvidenceURLs) == 0 {
+		return nil, errors.Invalid("evidence", errors.MsgOneRequired, "other", "evidence_urls")
+	}
+	if len(in.EvidenceURLs) > maxEvidenceURLs {
+		return nil, errors.Invalid("evidence_urls", errors.MsgMaxItems, "max", strconv.Itoa(maxEvidenceURLs))
+	}
+	for _, raw := range in.EvidenceURLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.Invalid("evidence_urls", errors.MsgURL)
+		}
+	}
+	var req models.PlaceVerificationRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		switch place.VerificationStatus {
+		case models.PlaceVerified:
+			return errors.Msg(errors.ErrConflict, errors.MsgAlreadyVerified)
+		case models.PlacePending:
+			return errors.Msg(errors.ErrConflict, errors.MsgVerificationPending)
+		}
+		req = models.PlaceVerificationRequest{
+			PlaceID:      placeID,
+			RequestedBy:  actorID,
+			Evidence:     in.Evidence,
+			EvidenceURLs: models.StringListJSON(in.EvidenceURLs),
+			Status:       models.VerificationPending,
+		}
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&place).Update("verification_status", models.PlacePending).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &place)
+		return recordPlaceHistory(tx, placeID, &actorID, models.PlaceHistoryVerificationRequest,
+			place.VerificationStatus, models.PlacePending, in.Evidence)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// List returns verification requests filtered by status (empty = all), oldest pending first.
+func (s *PlaceVerificationService) List(ctx context.Context, status string, page, limit int) ([]models.PlaceVerificationRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.PlaceVerificationRequest{})
+	switch status {
+	case "":
+	case models.VerificationPending, models.VerificationApproved, models.VerificationRejected:
+		q = q.Where("status = ?", status)
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "pending, approved, rejected")
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.PlaceVerificationRequest
+	err := q.Preload("Place").Order("created_at ASC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+// Approve marks the place verified. The reviewer cannot be the requester.
+func (s *PlaceVerificationService) Approve(ctx context.Context, reviewerID, requestID uuid.UUID, notes string) (*models.PlaceVerificationRequest, error) {
+	return s.decide(ctx, reviewerID, requestID, strings.TrimSpace(notes), true)
+}
+
+// Reject marks the place rejected; notes are required so the requester knows what to fix.
+func (s *PlaceVerificationService) Reject(ctx context.Context, reviewerID, requestID uuid.UUID, notes string) (*models.PlaceVerificationRequest, error) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return nil, errors.Invalid("notes", errors.MsgRequiredToReject)
+	}
+	return s.decide(ctx, reviewerID, requestID, notes, false)
+}
+
+func (s *PlaceVerificationService) decide(ctx context.Context, reviewerID, requestID uuid.UUID, notes string, approve bool) (*models.PlaceVerificationRequest, error) {
+	var req models.PlaceVerificationRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", requestID).First(&req).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRequestNotFound
+			}
+			return err
+		}
+		if req.Status != models.VerificationPending {
+			return errors.ErrRequestNotPending
+		}
+		if req.RequestedBy == reviewerID {
+			return errors.ErrSelfReview
+		}
+		var place models.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", req.PlaceID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		status, placeStatus, action := models.VerificationRejected, models.PlaceRejected, models.PlaceHistoryReject
+		if approve {
+			status, placeStatus, action = models.VerificationApproved, models.PlaceVerified, models.PlaceHistoryVerify
+		}
+		now := time.Now()
+		if err := tx.Model(&req).Updates(map[string]interface{}{
+			"status":       status,
+			"reviewer_id":  reviewerID,
+			"review_notes": notes,
+			"reviewed_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&place).Updates(map[string]interface{}{
+			"verification_status": placeStatus,
+			"is_verified":         approve,
+		}).Error; err != nil {
+			return err
+		}
+		invalidatePlaceTiles(tx, &place)
+		return recordPlaceHistory(tx, place.ID, &reviewerID, action, place.VerificationStatus, placeStatus, notes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
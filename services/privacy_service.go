@@ -0,0 +1,489 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultErasureGrace   = 30 * 24 * time.Hour
+	defaultExportTTL      = 7 * 24 * time.Hour
+	defaultExportLinkTTL  = time.Hour
+	privacyWorkerInterval = time.Minute
+	// staleExportAfter is how long an export may sit in building before the worker retries it
+	// (the instance building it probably stopped).
+	staleExportAfter = 30 * time.Minute
+)
+
+// PrivacyService implements GDPR data export and self-service account erasure.
+type PrivacyService struct {
+	db         *gorm.DB
+	dir        string
+	signingKey []byte
+	exportTTL  time.Duration
+	linkTTL    time.Duration
+	grace      time.Duration
+	placeOwner *uuid.UUID
+}
+
+// NewPrivacyService returns a PrivacyService configured from the environment:
+// EXPORT_DIR (default <tmp>/ducksrow-exports), EXPORT_SIGNING_KEY (default JWT_SECRET),
+// EXPORT_TTL (default 168h), EXPORT_LINK_TTL (default 1h), ACCOUNT_ERASURE_GRACE (default 720h)
+// and ERASURE_PLACE_OWNER_ID (user that inherits an erased user's places; unset = orphan them).
+func NewPrivacyService(db *gorm.DB) *PrivacyService {
+	s := &PrivacyService{
+		db:        db,
+		dir:       os.Getenv("EXPORT_DIR"),
+		exportTTL: defaultExportTTL,
+		linkTTL:   defaultExportLinkTTL,
+		grace:     defaultErasureGrace,
+	}
+	if s.dir == "" {
+		s.dir = filepath.Join(os.TempDir(), "ducksrow-exports")
+	}
+	key := os.Getenv("EXPORT_SIGNING_KEY")
+	if key == "" {
+		key = os.Getenv("JWT_SECRET")
+	}
+	s.signingKey = []byte(key)
+	if d, err := time.ParseDuration(os.Getenv("EXPORT_TTL")); err == nil && d > 0 {
+		s.exportTTL = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("EXPORT_LINK_TTL")); err == nil && d > 0 {
+		s.linkTTL = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("ACCOUNT_ERASURE_GRACE")); err == nil && d >= 0 {
+		s.grace = d
+	}
+	if id, err := uuid.Parse(os.Getenv("ERASURE_PLACE_OWNER_ID")); err == nil {
+		s.placeOwner = &id
+	}
+	return s
+}
+
+// ExportView is a DataExport with a signed download link once it is ready.
+type ExportView struct {
+	models.DataExport
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// RequestExport queues a data export for the user and starts building it in the background.
+// If an export is already pending or building, that one is returned instead.
+func (s *PrivacyService) RequestExport(ctx context.Context, userID uuid.UUID) (*ExportView, error) {
+	var exp models.DataExport
+	err := s.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, []string{models.ExportStatusPending, models.ExportStatusBuilding}).
+		First(&exp).Error
+	if err == nil {
+		return s.view(&exp), nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	exp = models.DataExport{UserID: userID, Status: models.ExportStatusPending}
+	if err := s.db.WithContext(ctx).Create(&exp).Error; err != nil {
+		return nil, err
+	}
+	go func(id uuid.UUID) {
+		if err := s.BuildExport(context.Background(), id); err != nil {
+			log.Printf("data export %s: %v", id, err)
+		}
+	}(exp.ID)
+	return s.view(&exp), nil
+}
+
+// GetExport returns one of the user's exports with a fresh signed link when ready.
+func (s *PrivacyService) GetExport(ctx context.Context, userID, exportID uuid.UUID) (*ExportView, error) {
+	var exp models.DataExport
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", exportID, userID).First(&exp).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrExportNotFound
+		}
+		return nil, err
+	}
+	return s.view(&exp), nil
+}
+
+// ExportFile checks a signed link and returns the path of the archive to serve.
+func (s *PrivacyService) ExportFile(ctx context.Context, exportID uuid.UUID, expires int64, sig string) (string, error) {
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(sig), []byte(s.sign(exportID, expires))) {
+		return "", errors.Msg(errors.ErrForbidden, errors.MsgDownloadLinkInvalid)
+	}
+	var exp models.DataExport
+	err := s.db.WithContext(ctx).
+		Where("id = ? AND status = ? AND expires_at > ?", exportID, models.ExportStatusReady, time.Now()).
+		First(&exp).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", errors.ErrExportNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return exp.FilePath, nil
+}
+
+// BuildExport writes the archive for a pending export. It claims the row first, so concurrent
+// calls (request goroutine and worker) build it once.
+func (s *PrivacyService) BuildExport(ctx context.Context, exportID uuid.UUID) error {
+	res := s.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", exportID, models.ExportStatusPending).
+		Update("status", models.ExportStatusBuilding)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+	var exp models.DataExport
+	if err := s.db.WithContext(ctx).Where("id = ?", exportID).First(&exp).Error; err != nil {
+		return err
+	}
+	path, size, err := s.writeArchive(ctx, exp.UserID, exp.ID)
+	if err != nil {
+		s.db.WithContext(ctx).Model(&exp).Updates(map[string]interface{}{
+			"status": models.ExportStatusFailed,
+			"error":  err.Error(),
+		})
+		return err
+	}
+	now := time.Now()
+	return s.db.WithContext(ctx).Model(&exp).Updates(map[string]interface{}{
+		"status":       models.ExportStatusReady,
+		"file_path":    path,
+		"size_bytes":   size,
+		"completed_at": now,
+		"expires_at":   now.Add(s.exportTTL),
+	}).Error
+}
+
+// ScheduleErasure marks the account for erasure after the grace period and returns the due time.
+// The last active admin, or the last active user able to manage roles, cannot schedule their own
+// erasure; erase re-checks both when it runs.
+func (s *PrivacyService) ScheduleErasure(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	var due time.Time
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
+		}
+		var user models.User
+		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrUserNotFound
+			}
+			return err
+		}
+		if user.ErasureDueAt != nil {
+			due = *user.ErasureDueAt
+			return nil
+		}
+		admin, err := isAdminUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if admin {
+			n, err := countActiveAdmins(tx)
+			if err != nil {
+				return err
+			}
+			if n <= 1 {
+				return errors.ErrLastAdmin
+			}
+		}
+		managing, err := canManageRoles(tx, userID)
+		if err != nil {
+			return err
+		}
+		if managing {
+			n, err := countRoleManagers(tx)
+			if err != nil {
+				return err
+			}
+			if n <= 1 {
+				return errors.ErrRolesManageLockout
+			}
+		}
+		due = time.Now().Add(s.grace)
+		if err := tx.Model(&user).Update("erasure_due_at", due).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      userID,
+			Action:       models.AuditActionErasure,
+			TargetUserID: &userID,
+			Detail:       "due " + due.UTC().Format("2006-01-02T15:04:05.000Z"),
+		}).Error
+	})
+	return due, err
+}
+
+// CancelErasure clears a scheduled erasure during the grace period.
+func (s *PrivacyService) CancelErasure(ctx context.Context, userID uuid.UUID) error {
+	return s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND erased_at IS NULL", userID).
+		Update("erasure_due_at", nil).Error
+}
+
+// EraseDue erases every account whose grace period has ended, including accounts an admin deleted
+// in the meantime. Failures (e.g. the user became the last admin) are logged and retried on the next run.
+func (s *PrivacyService) EraseDue(ctx context.Context) error {
+	var ids []uuid.UUID
+	if err := s.db.WithContext(ctx).Unscoped().Model(&models.User{}).
+		Where("erasure_due_at <= ? AND erased_at IS NULL", time.Now()).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.erase(ctx, id); err != nil {
+			log.Printf("account erasure %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// erase anonymises the user in place so audit entries keep pointing at a valid (but anonymous)
+// row: personal fields are overwritten, roles removed, places handed to ERASURE_PLACE_OWNER_ID or
+// orphaned, private plans and exports deleted, and the row soft-deleted. Public plans stay,
+// attributed to the anonymised user.
+func (s *PrivacyService) erase(ctx context.Context, userID uuid.UUID) error {
+	var files []string
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
+		}
+		var user models.User
+		// Unscoped: an account soft-deleted by an admin still holds personal data.
+		if err := tx.Unscoped().Where("id = ? AND erased_at IS NULL", userID).First(&user).Error; err != nil {
+			return err
+		}
+		admin, err := isAdminUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		managing, err := canManageRoles(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		if err := checkRBACInvariants(tx, admin, managing); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Place{}).Where("owner_id = ?", userID).
+			Update("owner_id", s.placeOwner).Error; err != nil {
+			return err
+		}
+		private := tx.Unscoped().Model(&models.Plan{}).Select("id").
+			Where("creator_id = ? AND visibility = ?", userID, models.VisibilityPrivate)
+		if err := tx.Unscoped().Where("plan_id IN (?)", private).Delete(&models.PlanItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("creator_id = ? AND visibility = ?", userID, models.VisibilityPrivate).
+			Delete(&models.Plan{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.EmailVerification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.DataExport{}).Where("user_id = ? AND file_path <> ''", userID).
+			Pluck("file_path", &files).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DataExport{}).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		anon := "erased-" + userID.String()
+		if err := tx.Unscoped().Model(&user).Updates(map[string]interface{}{
+			"username":      anon,
+			"name":          "",
+			"name_local":    "",
+			"email":         anon + "@invalid",
+			"password_hash": "!",
+			"avatar_url":    "",
+			"gender":        "",
+			"city":          "",
+			"date_of_birth": time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			"erased_at":     now,
+		}).Error; err != nil {
+			return err
+		}
+		// A no-op for an account that is already soft-deleted, which keeps its deletion time.
+		if err := tx.Delete(&user).Error; err != nil {
+			return err
+		}
+		invalidateUserPermissions(tx, userID)
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      userID,
+			Action:       models.AuditActionUserErase,
+			TargetUserID: &userID,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		os.Remove(f)
+	}
+	return nil
+}
+
+// PurgeExpiredExports deletes archives past their expiry and resets exports stuck in building.
+func (s *PrivacyService) PurgeExpiredExports(ctx context.Context) error {
+	var expired []models.DataExport
+	if err := s.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Find(&expired).Error; err != nil {
+		return err
+	}
+	for _, e := range expired {
+		if e.FilePath != "" {
+			os.Remove(e.FilePath)
+		}
+		if err := s.db.WithContext(ctx).Delete(&e).Error; err != nil {
+			return err
+		}
+	}
+	return s.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("status = ? AND updated_at < ?", models.ExportStatusBuilding, time.Now().Add(-staleExportAfter)).
+		Update("status", models.ExportStatusPending).Error
+}
+
+// RunPrivacyWorker builds queued exports, purges expired ones and erases accounts whose grace
+// period has ended, once a minute until ctx is done.
+func RunPrivacyWorker(ctx context.Context, db *gorm.DB) {
+	s := NewPrivacyService(db)
+	ticker := time.NewTicker(privacyWorkerInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.PurgeExpiredExports(ctx); err != nil {
+			log.Printf("privacy worker: purge exports: %v", err)
+		}
+		var pending []uuid.UUID
+		if err := db.WithContext(ctx).Model(&models.DataExport{}).
+			Where("status = ?", models.ExportStatusPending).Pluck("id", &pending).Error; err != nil {
+			log.Printf("privacy worker: list exports: %v", err)
+		}
+		for _, id := range pending {
+			if err := s.BuildExport(ctx, id); err != nil {
+				log.Printf("data export %s: %v", id, err)
+			}
+		}
+		if err := s.EraseDue(ctx); err != nil {
+			log.Printf("privacy worker: erase: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PrivacyService) view(exp *models.DataExport) *ExportView {
+	v := &ExportView{DataExport: *exp}
+	if exp.Status == models.ExportStatusReady && exp.ExpiresAt != nil {
+		expires := time.Now().Add(s.linkTTL)
+		if exp.ExpiresAt.Before(expires) {
+			expires = *exp.ExpiresAt
+		}
+		v.DownloadURL = fmt.Sprintf("/exports/%s/download?expires=%d&sig=%s",
+			exp.ID, expires.Unix(), s.sign(exp.ID, expires.Unix()))
+	}
+	return v
+}
+
+func (s *PrivacyService) sign(exportID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(exportID.String() + "|" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeArchive collects the user's data into <dir>/<exportID>.zip and returns its path and size.
+func (s *PrivacyService) writeArchive(ctx context.Context, userID, exportID uuid.UUID) (string, int64, error) {
+	db := s.db.WithContext(ctx)
+	var user models.User
+	if err := db.Unscoped().Where("id = ?", userID).First(&user).Error; err != nil {
+		return "", 0, err
+	}
+	roles, err := NewUserAdminService(s.db).roleAssignments(ctx, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	var places []models.Place
+	if err := db.Where("owner_id = ?", userID).Order("created_at").Find(&places).Error; err != nil {
+		return "", 0, err
+	}
+	var plans []models.Plan
+	if err := db.Where("creator_id = ?", userID).Preload("PlanItems").Order("created_at").Find(&plans).Error; err != nil {
+		return "", 0, err
+	}
+	var reviews []models.PlaceReview
+	if err := db.Where("author_id = ?", userID).Order("created_at").Find(&reviews).Error; err != nil {
+		return "", 0, err
+	}
+	var audit []models.RoleAuditLog
+	if err := db.Where("actor_id = ? OR target_user_id = ?", userID, userID).Order("created_at").Find(&audit).Error; err != nil {
+		return "", 0, err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return "", 0, err
+	}
+	path := filepath.Join(s.dir, exportID.String()+".zip")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", 0, err
+	}
+	zw := zip.NewWriter(f)
+	entries := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", user},
+		{"roles.json", roles},
+		{"places.json", places},
+		{"plans.json", plans},
+		{"reviews.json", reviews},
+		{"audit.json", audit},
+	}
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err == nil {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(e.data)
+		}
+		if err != nil {
+			zw.Close()
+			f.Close()
+			os.Remove(path)
+			return "", 0, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", 0, err
+	}
+	info, err := f.Stat()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", 0, err
+	}
+	return path, info.Size(), nil
+}
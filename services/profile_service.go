@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Profile constraints.
+const (
+	MinUserAge                    = 13
+	MaxUserAge                    = 120
+	defaultUsernameChangeCooldown = 30 * 24 * time.Hour
+	defaultEmailVerificationTTL   = 24 * time.Hour
+	profileDateLayout             = "2006-01-02"
+	maxProfileNameLength          = 100
+	maxAvatarURLLength            = 512
+)
+
+// AllowedGenders are the accepted values for User.Gender ("" = not specified).
+var AllowedGenders = []string{"", "male", "female", "non_binary", "other", "prefer_not_to_say"}
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,32}$`)
+
+// ProfileService reads and edits the current user's own profile.
+type ProfileService struct {
+	db        *gorm.DB
+	mailer    EmailSender
+	cooldown  time.Duration
+	verifyTTL time.Duration
+}
+
+// NewProfileService returns a ProfileService. USERNAME_CHANGE_COOLDOWN (default 720h) and
+// EMAIL_VERIFICATION_TTL (default 24h) are Go durations.
+func NewProfileService(db *gorm.DB) *ProfileService {
+	s := &ProfileService{
+		db:        db,
+		mailer:    defaultEmailSender,
+		cooldown:  defaultUsernameChangeCooldown,
+		verifyTTL: defaultEmailVerificationTTL,
+	}
+	if d, err := time.ParseDuration(os.Getenv("USERNAME_CHANGE_COOLDOWN")); err == nil && d >= 0 {
+		s.cooldown = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_TTL")); err == nil && d > 0 {
+		s.verifyTTL = d
+	}
+	return s
+}
+
+// Profile is the response shape of GET /api/me.
+type Profile struct {
+	models.User
+	PendingEmail  string                   `json:"pending_email,omitempty"`
+	Roles         []string                 `json:"roles"`
+	Contributions *models.ContributorStats `json:"contributions"`
+	// Warnings lists problems that did not undo a saved update, e.g. WarnVerificationEmailNotSent.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// WarnVerificationEmailNotSent is returned in Profile.Warnings when the email change was saved but
+// its verification email could not be sent; sending the same email again retries.
+const WarnVerificationEmailNotSent = "verification_email_not_sent"
+
+// ProfileUpdate holds the PATCH /api/me fields; nil fields are left unchanged.
+// DateOfBirth is YYYY-MM-DD.
+type ProfileUpdate struct {
+	Username    *string `json:"username"`
+	Name        *string `json:"name"`
+	NameLocal   *string `json:"name_local"`
+	DateOfBirth *string `json:"date_of_birth"`
+	Gender      *string `json:"gender"`
+	AvatarURL   *string `json:"avatar_url"`
+	Email       *string `json:"email"`
+}
+
+// Get returns the user's profile with role slugs and any pending email change.
+func (s *ProfileService) Get(ctx context.Context, userID uuid.UUID) (*Profile, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	roles, err := NewAuthService(s.db).UserRoleSlugs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	contributions, err := NewPlaceSuggestionService(s.db).Contributor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p := &Profile{User: user, Roles: roles, Contributions: contributions}
+	var pending models.EmailVerification
+	err = s.db.WithContext(ctx).
+		Where("user_id = ? AND consumed_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		First(&pending).Error
+	if err == nil {
+		p.PendingEmail = pending.Email
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update validates and applies in. A username change is rejected with ErrUsernameCooldown when
+// the previous change is more recent than the cooldown. An email change is not applied directly:
+// a verification token is sent to the new address and the change waits for VerifyEmail.
+func (s *ProfileService) Update(ctx context.Context, userID uuid.UUID, in ProfileUpdate) (*Profile, error) {
+	updates := map[string]interface{}{}
+	if in.Name != nil {
+		v := strings.TrimSpace(*in.Name)
+		if v == "" || len(v) > maxProfileNameLength {
+			return nil, errors.Invalid("name", errors.MsgLength, "min", "1", "max", strconv.Itoa(maxProfileNameLength))
+		}
+		updates["name"] = v
+	}
+	if in.NameLocal != nil {
+		v := strings.TrimSpace(*in.NameLocal)
+		if len(v) > maxProfileNameLength {
+			return nil, errors.Invalid("name_local", errors.MsgMaxLength, "max", strconv.Itoa(maxProfileNameLength))
+		}
+		updates["name_local"] = v
+	}
+	if in.DateOfBirth != nil {
+		dob, err := parseDateOfBirth(*in.DateOfBirth, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		updates["date_of_birth"] = dob
+	}
+	if in.Gender != nil {
+		v := strings.ToLower(strings.TrimSpace(*in.Gender))
+		if !stringIn(AllowedGenders, v) {
+			return nil, errors.Invalid("gender", errors.MsgOneOf, "values", strings.Join(AllowedGenders[1:], ", "))
+		}
+		updates["gender"] = v
+	}
+	if in.AvatarURL != nil {
+		v := strings.TrimSpace(*in.AvatarURL)
+		if v != "" {
+			u, err := url.Parse(v)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(v) > maxAvatarURLLength {
+				return nil, errors.Invalid("avatar_url", errors.MsgURL)
+			}
+		}
+		updates["avatar_url"] = v
+	}
+	var newEmail string
+	if in.Email != nil {
+		addr, err := mail.ParseAddress(strings.TrimSpace(*in.Email))
+		if err != nil || addr.Name != "" {
+			return nil, errors.Invalid("email", errors.MsgInvalidValue)
+		}
+		newEmail = strings.ToLower(addr.Address)
+	}
+
+	var token string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrUserNotFound
+			}
+			return err
+		}
+		if in.Username != nil && strings.TrimSpace(*in.Username) != user.Username {
+			v := strings.TrimSpace(*in.Username)
+			if !usernamePattern.MatchString(v) {
+				return errors.Invalid("username", errors.MsgUsernameFormat)
+			}
+			if user.UsernameChangedAt != nil && time.Since(*user.UsernameChangedAt) < s.cooldown {
+				return errors.Msg(errors.ErrUsernameCooldown, errors.MsgUsernameCooldown,
+					"until", user.UsernameChangedAt.Add(s.cooldown).UTC().Format("2006-01-02T15:04:05.000Z"))
+			}
+			var n int64
+			if err := tx.Unscoped().Model(&models.User{}).
+				Where("LOWER(username) = LOWER(?) AND id <> ?", v, userID).Count(&n).Error; err != nil {
+				return err
+			}
+			if n > 0 {
+				return errors.Msg(errors.ErrConflict, errors.MsgTaken, "field", "username")
+			}
+			updates["username"] = v
+			updates["username_changed_at"] = time.Now()
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&user).Updates(updates).Error; err != nil {
+				if isUniqueViolation(err) {
+					return errors.ErrConflict
+				}
+				return err
+			}
+		}
+		if newEmail != "" && newEmail != strings.ToLower(user.Email) {
+			if err := emailAvailable(tx, newEmail, userID); err != nil {
+				return err
+			}
+			t, err := s.startEmailVerification(tx, userID, newEmail)
+			if err != nil {
+				return err
+			}
+			token = t
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	if token != "" {
+		// The update is already committed; report the failed send instead of failing the request.
+		if err := s.mailer.SendEmailVerification(ctx, newEmail, token); err != nil {
+			log.Printf("email verification for user %s not sent: %v", userID, err)
+			warnings = append(warnings, WarnVerificationEmailNotSent)
+		}
+	}
+	p, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p.Warnings = warnings
+	return p, nil
+}
+
+// VerifyEmail applies the pending email change identified by token.
+func (s *ProfileService) VerifyEmail(ctx context.Context, userID uuid.UUID, token string) (*Profile, error) {
+	sum := sha256.Sum256([]byte(token))
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var v models.EmailVerification
+		err := tx.Where("token_hash = ? AND user_id = ? AND consumed_at IS NULL AND expires_at > ?",
+			hex.EncodeToString(sum[:]), userID, time.Now()).First(&v).Error
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrVerificationInvalid
+		}
+		if err != nil {
+			return err
+		}
+		if err := emailAvailable(tx, v.Email, userID); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("email", v.Email).Error; err != nil {
+			if isUniqueViolation(err) {
+				return errors.Msg(errors.ErrConflict, errors.MsgTaken, "field", "email")
+			}
+			return err
+		}
+		return tx.Model(&models.EmailVerification{}).
+			Where("user_id = ? AND consumed_at IS NULL", userID).
+			Update("consumed_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, userID)
+}
+
+// startEmailVerification supersedes earlier pending changes and stores a new token for email.
+func (s *ProfileService) startEmailVerification(tx *gorm.DB, userID uuid.UUID, email string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(token))
+	now := time.Now()
+	if err := tx.Model(&models.EmailVerification{}).
+		Where("user_id = ? AND consumed_at IS NULL", userID).
+		Update("expires_at", now).Error; err != nil {
+		return "", err
+	}
+	err := tx.Create(&models.EmailVerification{
+		UserID:    userID,
+		Email:     email,
+		TokenHash: hex.EncodeToString(sum[:]),
+		ExpiresAt: now.Add(s.verifyTTL),
+	}).Error
+	return token, err
+}
+
+func emailAvailable(tx *gorm.DB, email string, userID uuid.UUID) error {
+	var n int64
+	if err := tx.Unscoped().Model(&models.User{}).
+		Where("LOWER(email) = ? AND id <> ?", email, userID).Count(&n).Error; err != nil {
+		return err
+	}
+	if n > 0 {
+		return errors.Msg(errors.ErrConflict, errors.MsgTaken, "field", "email")
+	}
+	return nil
+}
+
+// parseDateOfBirth parses YYYY-MM-DD and checks the resulting age is within [MinUserAge, MaxUserAge].
+func parseDateOfBirth(s string, now time.Time) (time.Time, error) {
+	dob, err := time.Parse(profileDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, errors.Invalid("date_of_birth", errors.MsgFormat, "format", "YYYY-MM-DD")
+	}
+	if dob.After(now.AddDate(-MinUserAge, 0, 0)) {
+		return time.Time{}, errors.Invalid("date_of_birth", errors.MsgMinAge, "age", strconv.Itoa(MinUserAge))
+	}
+	if dob.Before(now.AddDate(-MaxUserAge, 0, 0)) {
+		return time.Time{}, errors.Invalid("date_of_birth", errors.MsgMaxAge, "age", strconv.Itoa(MaxUserAge))
+	}
+	return dob, nil
+}
+
+func stringIn(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"ducksrow/backend/errors"
+	"ducksrow/backend/permissions"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// rbacLockKey is the advisory lock taken by every RBAC mutation that must keep the
+// admin / roles:manage invariants. Serialises concurrent unassign / update / delete.
+const rbacLockKey = 727_001
+
+// lockRBAC takes a transaction-scoped advisory lock so invariant checks see a stable state.
+func lockRBAC(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", rbacLockKey).Error
+}
+
+// activeUserCond restricts a users join (aliased u) to non-deleted, non-suspended users.
+const activeUserCond = "u.deleted_at IS NULL AND (u.suspended_at IS NULL OR (u.suspended_until IS NOT NULL AND u.suspended_until <= NOW()))"
+
+// countActiveAdmins returns the number of active (non-deleted, non-suspended) users holding the admin role.
+func countActiveAdmins(tx *gorm.DB) (int64, error) {
+	var n int64
+	err := tx.Raw(
+		`SELECT COUNT(DISTINCT ur.user_id) FROM user_roles ur
+		 JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
+		 JOIN users u ON u.id = ur.user_id AND ` + activeUserCond + `
+		 WHERE r.slug = 'admin'`,
+	).Scan(&n).Error
+	return n, err
+}
+
+// countRoleManagers returns the number of active users who can manage roles
+// (via a role holding roles:manage, or via the admin role).
+func countRoleManagers(tx *gorm.DB) (int64, error) {
+	var n int64
+	err := tx.Raw(
+		`SELECT COUNT(DISTINCT ur.user_id) FROM user_roles ur
+		 JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
+		 JOIN users u ON u.id = ur.user_id AND `+activeUserCond+`
+		 LEFT JOIN role_permissions rp ON rp.role_id = ur.role_id
+		 WHERE r.slug = 'admin' OR rp.permission = ?`,
+		permissions.RolesManage,
+	).Scan(&n).Error
+	return n, err
+}
+
+// canManageRoles returns true if the user can manage roles (via roles:manage or admin).
+func canManageRoles(tx *gorm.DB, userID uuid.UUID) (bool, error) {
+	var one int
+	err := tx.Raw(
+		`SELECT 1 FROM user_roles ur
+		 JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
+		 LEFT JOIN role_permissions rp ON rp.role_id = ur.role_id
+		 WHERE ur.user_id = ? AND (r.slug = 'admin' OR rp.permission = ?)
+		 LIMIT 1`,
+		userID, permissions.RolesManage,
+	).Scan(&one).Error
+	return one == 1, err
+}
+
+// isAdminUser returns true if the user holds the admin role.
+func isAdminUser(tx *gorm.DB, userID uuid.UUID) (bool, error) {
+	var one int
+	err := tx.Raw(
+		`SELECT 1 FROM user_roles ur
+		 JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL
+		 WHERE ur.user_id = ? AND r.slug = 'admin'
+		 LIMIT 1`,
+		userID,
+	).Scan(&one).Error
+	return one == 1, err
+}
+
+// roleGrantsRolesManage returns true if the role is admin or carries roles:manage.
+func roleGrantsRolesManage(tx *gorm.DB, roleID uuid.UUID, slug string) (bool, error) {
+	if slug == "admin" {
+		return true, nil
+	}
+	var n int64
+	err := tx.Table("role_permissions").
+		Where("role_id = ? AND permission = ?", roleID, permissions.RolesManage).
+		Count(&n).Error
+	return n > 0, err
+}
+
+// checkRBACInvariants is run inside a transaction after a mutation. adminTouched and
+// managersTouched limit the checks to invariants the mutation could have broken, so an
+// unrelated change on a database without any admin yet is not rejected.
+func checkRBACInvariants(tx *gorm.DB, adminTouched, managersTouched bool) error {
+	if adminTouched {
+		n, err := countActiveAdmins(tx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.ErrLastAdmin
+		}
+	}
+	if managersTouched {
+		n, err := countRoleManagers(tx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.ErrRolesManageLockout
+		}
+	}
+	return nil
+}
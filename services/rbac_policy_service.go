@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+	"ducksrow/backend/permissions"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// RBACPolicy is the declarative description of roles and their permissions (YAML or JSON).
+type RBACPolicy struct {
+	Roles []PolicyRole `json:"roles" yaml:"roles"`
+}
+
+// PolicyRole is one role in an RBACPolicy. System is informational: apply never changes IsSystem.
+type PolicyRole struct {
+	Slug        string   `json:"slug" yaml:"slug"`
+	Name        string   `json:"name" yaml:"name"`
+	System      bool     `json:"system,omitempty" yaml:"system,omitempty"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+// Policy change operations.
+const (
+	PolicyOpCreate = "create"
+	PolicyOpUpdate = "update"
+	PolicyOpDelete = "delete"
+)
+
+// PolicyChange is one step needed to converge the DB to a policy.
+// Blocked is set when the step would violate IsSystem protection; apply refuses plans with blocked steps.
+type PolicyChange struct {
+	Op      string   `json:"op"`
+	Slug    string   `json:"slug"`
+	Name    string   `json:"name,omitempty"`
+	Add     []string `json:"add,omitempty"`
+	Remove  []string `json:"remove,omitempty"`
+	Blocked string   `json:"blocked,omitempty"`
+}
+
+// String renders the change as one plan line.
+func (c PolicyChange) String() string {
+	var b strings.Builder
+	switch c.Op {
+	case PolicyOpCreate:
+		b.WriteString("+ ")
+	case PolicyOpDelete:
+		b.WriteString("- ")
+	default:
+		b.WriteString("~ ")
+	}
+	b.WriteString(c.Slug)
+	if c.Name != "" {
+		fmt.Fprintf(&b, " name=%q", c.Name)
+	}
+	for _, p := range c.Add {
+		b.WriteString(" +" + p)
+	}
+	for _, p := range c.Remove {
+		b.WriteString(" -" + p)
+	}
+	if c.Blocked != "" {
+		b.WriteString(" [blocked: " + c.Blocked + "]")
+	}
+	return b.String()
+}
+
+// ParsePolicy decodes a policy; JSON when filename ends in .json, YAML otherwise.
+// Validates slugs, names and permission keys, and rejects duplicate slugs.
+func ParsePolicy(filename string, data []byte) (*RBACPolicy, error) {
+	var p RBACPolicy
+	var err error
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		err = json.Unmarshal(data, &p)
+	} else {
+		err = yaml.Unmarshal(data, &p)
+	}
+	if err != nil {
+		return nil, errors.Invalid("policy", errors.MsgInvalidReason, "reason", err.Error())
+	}
+	seen := make(map[string]bool)
+	for _, r := range p.Roles {
+		if !slugRegex.MatchString(r.Slug) || r.Name == "" {
+			return nil, errors.Invalid("roles", errors.MsgRoleDefinition, "slug", r.Slug)
+		}
+		if seen[r.Slug] {
+			return nil, errors.Invalid("roles", errors.MsgDuplicateValue, "value", r.Slug)
+		}
+		seen[r.Slug] = true
+		for _, perm := range r.Permissions {
+			if !permissions.IsValid(perm) {
+				return nil, fmt.Errorf("%w: role %q: %s", errors.ErrPermissionInvalid, r.Slug, perm)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// EncodePolicy renders a policy as "yaml" or "json".
+func EncodePolicy(p *RBACPolicy, format string) ([]byte, error) {
+	if format == "json" {
+		return json.MarshalIndent(p, "", "  ")
+	}
+	return yaml.Marshal(p)
+}
+
+// RBACPolicyService diffs, applies and exports declarative RBAC policies.
+type RBACPolicyService struct {
+	db *gorm.DB
+}
+
+// NewRBACPolicyService returns an RBACPolicyService.
+func NewRBACPolicyService(db *gorm.DB) *RBACPolicyService {
+	return &RBACPolicyService{db: db}
+}
+
+// Export returns the current roles and permissions as a policy, sorted by slug.
+func (s *RBACPolicyService) Export(ctx context.Context) (*RBACPolicy, error) {
+	state, err := s.load(s.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	p := &RBACPolicy{Roles: make([]PolicyRole, 0, len(state))}
+	for _, r := range state {
+		p.Roles = append(p.Roles, PolicyRole{
+			Slug:        r.role.Slug,
+			Name:        r.role.Name,
+			System:      r.role.IsSystem,
+			Permissions: r.perms,
+		})
+	}
+	sort.Slice(p.Roles, func(i, j int) bool { return p.Roles[i].Slug < p.Roles[j].Slug })
+	return p, nil
+}
+
+// Plan returns the changes needed to converge the DB to p. With prune, roles missing from p are deleted.
+func (s *RBACPolicyService) Plan(ctx context.Context, p *RBACPolicy, prune bool) ([]PolicyChange, error) {
+	return s.plan(s.db.WithContext(ctx), p, prune)
+}
+
+// Apply converges the DB to p in one transaction and returns the changes made.
+// Fails with ErrSystemRoleProtected if any change is blocked. Every change writes a RoleAuditLog
+// entry attributed to actorID.
+func (s *RBACPolicyService) Apply(ctx context.Context, actorID uuid.UUID, p *RBACPolicy, prune bool) ([]PolicyChange, error) {
+	var changes []PolicyChange
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
+		}
+		var err error
+		changes, err = s.plan(tx, p, prune)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			if c.Blocked != "" {
+				return fmt.Errorf("%w: %s", errors.ErrSystemRoleProtected, c)
+			}
+		}
+		roles := NewRoleService(tx)
+		want := make(map[string]PolicyRole)
+		for _, r := range p.Roles {
+			want[r.Slug] = r
+		}
+		for _, c := range changes {
+			var roleID uuid.UUID
+			switch c.Op {
+			case PolicyOpCreate:
+				dto, err := roles.Create(ctx, c.Slug, want[c.Slug].Name, want[c.Slug].Permissions)
+				if err != nil {
+					return fmt.Errorf("create %s: %w", c.Slug, err)
+				}
+				roleID = dto.ID
+			case PolicyOpUpdate, PolicyOpDelete:
+				var role models.Role
+				if err := tx.Where("slug = ?", c.Slug).First(&role).Error; err != nil {
+					return err
+				}
+				roleID = role.ID
+				if c.Op == PolicyOpDelete {
+					if err := roles.Delete(ctx, role.ID); err != nil {
+						return fmt.Errorf("delete %s: %w", c.Slug, err)
+					}
+					break
+				}
+				var name *string
+				if c.Name != "" {
+					name = &c.Name
+				}
+				var perms []string
+				if len(c.Add)+len(c.Remove) > 0 {
+					perms = append([]string{}, want[c.Slug].Permissions...)
+				}
+				if _, err := roles.Update(ctx, role.ID, name, perms); err != nil {
+					return fmt.Errorf("update %s: %w", c.Slug, err)
+				}
+			}
+			audit := models.RoleAuditLog{
+				ActorID:  actorID,
+				Action:   policyAuditAction(c.Op),
+				RoleID:   roleID,
+				RoleSlug: c.Slug,
+				Detail:   c.String(),
+			}
+			if err := tx.Create(&audit).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func policyAuditAction(op string) string {
+	switch op {
+	case PolicyOpCreate:
+		return models.AuditActionRoleCreate
+	case PolicyOpDelete:
+		return models.AuditActionRoleDelete
+	default:
+		return models.AuditActionRoleUpdate
+	}
+}
+
+type roleState struct {
+	role  models.Role
+	perms []string
+}
+
+// load returns every non-deleted role with its sorted permission keys, keyed by slug.
+func (s *RBACPolicyService) load(tx *gorm.DB) (map[string]*roleState, error) {
+	var roles []models.Role
+	if err := tx.Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	state := make(map[string]*roleState, len(roles))
+	byID := make(map[uuid.UUID]*roleState, len(roles))
+	for _, r := range roles {
+		rs := &roleState{role: r, perms: []string{}}
+		state[r.Slug] = rs
+		byID[r.ID] = rs
+	}
+	var rps []models.RolePermission
+	if err := tx.Find(&rps).Error; err != nil {
+		return nil, err
+	}
+	for _, rp := range rps {
+		if rs, ok := byID[rp.RoleID]; ok {
+			rs.perms = append(rs.perms, rp.Permission)
+		}
+	}
+	for _, rs := range state {
+		sort.Strings(rs.perms)
+	}
+	return state, nil
+}
+
+func (s *RBACPolicyService) plan(tx *gorm.DB, p *RBACPolicy, prune bool) ([]PolicyChange, error) {
+	state, err := s.load(tx)
+	if err != nil {
+		return nil, err
+	}
+	var changes []PolicyChange
+	inPolicy := make(map[string]bool)
+	for _, r := range p.Roles {
+		inPolicy[r.Slug] = true
+		cur, ok := state[r.Slug]
+		if !ok {
+			// Roles are created through RoleService.Create, which requires a permission; existing
+			// roles may be emptied by an update.
+			if len(r.Permissions) == 0 {
+				return nil, errors.Invalid("roles", errors.MsgRolePermissions, "slug", r.Slug)
+			}
+			perms := append([]string(nil), r.Permissions...)
+			sort.Strings(perms)
+			changes = append(changes, PolicyChange{Op: PolicyOpCreate, Slug: r.Slug, Name: r.Name, Add: perms})
+			continue
+		}
+		c := PolicyChange{Op: PolicyOpUpdate, Slug: r.Slug}
+		if cur.role.Name != r.Name {
+			c.Name = r.Name
+		}
+		c.Add, c.Remove = diffPerms(cur.perms, r.Permissions)
+		if c.Name == "" && len(c.Add) == 0 && len(c.Remove) == 0 {
+			continue
+		}
+		if cur.role.IsSystem && len(c.Remove) > 0 {
+			c.Blocked = "system role permissions can only be added"
+		}
+		changes = append(changes, c)
+	}
+	if prune {
+		slugs := make([]string, 0, len(state))
+		for slug := range state {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+		for _, slug := range slugs {
+			if inPolicy[slug] {
+				continue
+			}
+			c := PolicyChange{Op: PolicyOpDelete, Slug: slug}
+			if state[slug].role.IsSystem {
+				c.Blocked = "system role cannot be deleted"
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// diffPerms returns the permissions to add and remove to go from cur to want (both sorted).
+func diffPerms(cur, want []string) (add, remove []string) {
+	have := make(map[string]bool, len(cur))
+	for _, p := range cur {
+		have[p] = true
+	}
+	wanted := make(map[string]bool, len(want))
+	for _, p := range want {
+		wanted[p] = true
+		if !have[p] {
+			add = append(add, p)
+		}
+	}
+	for _, p := range cur {
+		if !wanted[p] {
+			remove = append(remove, p)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+	"ducksrow/backend/permissions"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxReviewText is the longest review or reply accepted, in characters.
+const maxReviewText = 5000
+
+// reviewSorts maps the ?sort= values of the review list to ORDER BY clauses.
+var reviewSorts = map[string]string{
+	"newest":  "created_at DESC",
+	"oldest":  "created_at ASC",
+	"highest": "rating DESC, created_at DESC",
+	"lowest":  "rating ASC, created_at DESC",
+}
+
+// ReviewService handles place reviews, owner replies and the rating aggregate on places.
+type ReviewService struct {
+	db *gorm.DB
+}
+
+// NewReviewService returns a ReviewService.
+func NewReviewService(db *gorm.DB) *ReviewService {
+	return &ReviewService{db: db}
+}
+
+// ReviewInput is the body of POST /api/places/:id/reviews and PATCH /api/places/:id/reviews/:rid
+// (omitted fields are left unchanged on update; an empty visited_on clears it).
+type ReviewInput struct {
+	Rating    *int    `json:"rating"`
+	Text      *string `json:"text"`
+	VisitedOn *string `json:"visited_on"` // YYYY-MM-DD
+}
+
+// ReviewView is a review with its author's username.
+type ReviewView struct {
+	models.PlaceReview
+	AuthorUsername string `json:"author_username"`
+}
+
+// Create records the author's review of the place and updates the place's rating.
+// Each user reviews a place once; owners cannot review their own place.
+func (s *ReviewService) Create(ctx context.Context, authorID, placeID uuid.UUID, in ReviewInput) (*models.PlaceReview, error) {
+	if in.Rating == nil {
+		return nil, errors.Invalid("rating", errors.MsgRequired)
+	}
+	review := models.PlaceReview{PlaceID: placeID, AuthorID: authorID}
+	if err := applyReviewInput(&review, in); err != nil {
+		return nil, err
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		place, err := lockReviewedPlace(tx, placeID)
+		if err != nil {
+			return err
+		}
+		if place.OwnerID != nil && *place.OwnerID == authorID {
+			return errors.Msg(errors.ErrForbidden, errors.MsgReviewOwnPlace)
+		}
+		var n int64
+		if err := tx.Model(&models.PlaceReview{}).
+			Where("place_id = ? AND author_id = ?", placeID, authorID).Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return errors.Msg(errors.ErrConflict, errors.MsgAlreadyReviewed)
+		}
+		if err := tx.Create(&review).Error; err != nil {
+			if isUniqueViolation(err) {
+				return errors.Msg(errors.ErrConflict, errors.MsgAlreadyReviewed)
+			}
+			return err
+		}
+		return updatePlaceRating(tx, placeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// List returns the place's visible reviews sorted by sort (newest, oldest, highest, lowest; default newest).
+func (s *ReviewService) List(ctx context.Context, placeID uuid.UUID, sort string, page, limit int) ([]ReviewView, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if sort == "" {
+		sort = "newest"
+	}
+	order, ok := reviewSorts[sort]
+	if !ok {
+		return nil, 0, errors.Invalid("sort", errors.MsgOneOf, "values", "newest, oldest, highest, lowest")
+	}
+	db := s.db.WithContext(ctx)
+	if err := db.Where("id = ?", placeID).First(&models.Place{}).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, 0, errors.ErrPlaceNotFound
+		}
+		return nil, 0, err
+	}
+	q := db.Model(&models.PlaceReview{}).Where("place_id = ? AND hidden_at IS NULL", placeID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []models.PlaceReview
+	if err := q.Order(order).Offset((page - 1) * limit).Limit(limit).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	views, err := s.views(db, reviews)
+	return views, total, err
+}
+
+// Update edits the author's own review and updates the place's rating.
+func (s *ReviewService) Update(ctx context.Context, authorID, placeID, reviewID uuid.UUID, in ReviewInput) (*models.PlaceReview, error) {
+	var review models.PlaceReview
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockReviewedPlace(tx, placeID); err != nil {
+			return err
+		}
+		if err := loadReview(tx, placeID, reviewID, &review); err != nil {
+			return err
+		}
+		if review.AuthorID != authorID {
+			return errors.Msg(errors.ErrForbidden, errors.MsgReviewEditAuthor)
+		}
+		if err := applyReviewInput(&review, in); err != nil {
+			return err
+		}
+		if err := tx.Model(&review).Select("rating", "text", "visited_on", "updated_at").Updates(&review).Error; err != nil {
+			return err
+		}
+		return updatePlaceRating(tx, placeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Delete removes a review; the author may delete their own, reviews:delete anyone's.
+func (s *ReviewService) Delete(ctx context.Context, actorID, placeID, reviewID uuid.UUID) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockReviewedPlace(tx, placeID); err != nil {
+			return err
+		}
+		var review models.PlaceReview
+		if err := loadReview(tx, placeID, reviewID, &review); err != nil {
+			return err
+		}
+		if review.AuthorID != actorID {
+			allowed, err := NewPermissionService(s.db).HasPermission(ctx, actorID, permissions.ReviewsDelete)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return errors.Msg(errors.ErrForbidden, errors.MsgReviewDeleteAuthor)
+			}
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		return updatePlaceRating(tx, placeID)
+	})
+}
+
+// Reply sets the place owner's public reply to a review; an empty text removes it.
+func (s *ReviewService) Reply(ctx context.Context, ownerID, placeID, reviewID uuid.UUID, text string) (*models.PlaceReview, error) {
+	text = strings.TrimSpace(text)
+	if len([]rune(text)) > maxReviewText {
+		return nil, errors.Invalid("text", errors.MsgMaxLength, "max", strconv.Itoa(maxReviewText))
+	}
+	if err := checkBannedWords("text", text); err != nil {
+		return nil, err
+	}
+	var review models.PlaceReview
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var place models.Place
+		if err := tx.Where("id = ?", placeID).First(&place).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrPlaceNotFound
+			}
+			return err
+		}
+		if place.OwnerID == nil || *place.OwnerID != ownerID {
+			return errors.Msg(errors.ErrForbidden, errors.MsgReplyNotOwner)
+		}
+		if err := loadReview(tx, placeID, reviewID, &review); err != nil {
+			return err
+		}
+		review.Reply, review.ReplyAuthorID, review.RepliedAt = text, nil, nil
+		if text != "" {
+			now := time.Now()
+			review.ReplyAuthorID, review.RepliedAt = &ownerID, &now
+		}
+		// UpdateColumns keeps updated_at for the author's own edits.
+		return tx.Model(&review).UpdateColumns(map[string]interface{}{
+			"reply":           review.Reply,
+			"reply_author_id": review.ReplyAuthorID,
+			"replied_at":      review.RepliedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (s *ReviewService) views(db *gorm.DB, reviews []models.PlaceReview) ([]ReviewView, error) {
+	ids := make([]uuid.UUID, 0, len(reviews))
+	for _, r := range reviews {
+		ids = append(ids, r.AuthorID)
+	}
+	var users []models.User
+	if len(ids) > 0 {
+		if err := db.Unscoped().Select("id", "username").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+	out := make([]ReviewView, len(reviews))
+	for i, r := range reviews {
+		out[i] = ReviewView{PlaceReview: r, AuthorUsername: names[r.AuthorID]}
+	}
+	return out, nil
+}
+
+// applyReviewInput validates the set fields of in and copies them onto the review.
+func applyReviewInput(r *models.PlaceReview, in ReviewInput) error {
+	if in.Rating != nil {
+		if *in.Rating < models.ReviewRatingMin || *in.Rating > models.ReviewRatingMax {
+			return errors.Invalid("rating", errors.MsgRange, "min", strconv.Itoa(models.ReviewRatingMin), "max", strconv.Itoa(models.ReviewRatingMax))
+		}
+		r.Rating = *in.Rating
+	}
+	if in.Text != nil {
+		text := strings.TrimSpace(*in.Text)
+		if len([]rune(text)) > maxReviewText {
+			return errors.Invalid("text", errors.MsgMaxLength, "max", strconv.Itoa(maxReviewText))
+		}
+		if err := checkBannedWords("text", text); err != nil {
+			return err
+		}
+		r.Text = text
+	}
+	if in.VisitedOn != nil {
+		r.VisitedOn = nil
+		if *in.VisitedOn != "" {
+			d, err := time.Parse("2006-01-02", *in.VisitedOn)
+			if err != nil {
+				return errors.Invalid("visited_on", errors.MsgFormat, "format", "YYYY-MM-DD")
+			}
+			if d.After(time.Now()) {
+				return errors.Invalid("visited_on", errors.MsgFutureDate)
+			}
+			r.VisitedOn = &d
+		}
+	}
+	return nil
+}
+
+// lockReviewedPlace locks the place row so concurrent review changes update its rating in turn.
+func lockReviewedPlace(tx *gorm.DB, placeID uuid.UUID) (*models.Place, error) {
+	var place models.Place
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", placeID).First(&place).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPlaceNotFound
+		}
+		return nil, err
+	}
+	return &place, nil
+}
+
+func loadReview(tx *gorm.DB, placeID, reviewID uuid.UUID, review *models.PlaceReview) error {
+	err := tx.Where("id = ? AND place_id = ?", reviewID, placeID).First(review).Error
+	if err == gorm.ErrRecordNotFound {
+		return errors.ErrReviewNotFound
+	}
+	return err
+}
+
+// updatePlaceRating recomputes Place.RatingAverage and Place.RatingCount from the place's visible
+// reviews.
+// Call inside the transaction that changed them.
+func updatePlaceRating(tx *gorm.DB, placeID uuid.UUID) error {
+	var agg struct {
+		Count   int
+		Average float64
+	}
+	if err := tx.Model(&models.PlaceReview{}).
+		Select("COUNT(*) AS count, COALESCE(AVG(rating), 0) AS average").
+		Where("place_id = ? AND hidden_at IS NULL", placeID).Scan(&agg).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Place{}).Where("id = ?", placeID).UpdateColumns(map[string]interface{}{
+		"rating_count":   agg.Count,
+		"rating_average": agg.Average,
+	}).Error
+}
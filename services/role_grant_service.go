@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultGrantTTL is how long a pending grant stays approvable when ROLE_GRANT_TTL is unset.
+const defaultGrantTTL = 72 * time.Hour
+
+// RoleGrantService routes assignments of sensitive roles through two-person approval.
+// A role is sensitive if its slug is listed in SENSITIVE_ROLES (comma-separated, default "admin")
+// or if it carries roles:manage.
+type RoleGrantService struct {
+	db        *gorm.DB
+	sensitive map[string]bool
+	ttl       time.Duration
+}
+
+// NewRoleGrantService returns a RoleGrantService configured from SENSITIVE_ROLES and ROLE_GRANT_TTL.
+func NewRoleGrantService(db *gorm.DB) *RoleGrantService {
+	raw := os.Getenv("SENSITIVE_ROLES")
+	if raw == "" {
+		raw = "admin"
+	}
+	sensitive := make(map[string]bool)
+	for _, slug := range strings.Split(raw, ",") {
+		if slug = strings.TrimSpace(slug); slug != "" {
+			sensitive[slug] = true
+		}
+	}
+	ttl := defaultGrantTTL
+	if d, err := time.ParseDuration(os.Getenv("ROLE_GRANT_TTL")); err == nil && d > 0 {
+		ttl = d
+	}
+	return &RoleGrantService{db: db, sensitive: sensitive, ttl: ttl}
+}
+
+// GrantResult is returned by Grant: exactly one of Assignment or Pending is set.
+type GrantResult struct {
+	Assignment *AssignResult
+	Pending    *models.RoleGrantRequest
+}
+
+// Grant assigns roleID to targetUserID, or records a pending request when the role is sensitive.
+// Already-held roles return the existing assignment; a duplicate pending request returns the open one.
+func (s *RoleGrantService) Grant(ctx context.Context, actorID, targetUserID, roleID uuid.UUID, reason string) (*GrantResult, error) {
+	var role models.Role
+	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	sensitive, err := s.isSensitive(s.db.WithContext(ctx), &role)
+	if err != nil {
+		return nil, err
+	}
+	assignSvc := NewUserRoleService(s.db)
+	if !sensitive {
+		res, err := assignSvc.Assign(ctx, actorID, targetUserID, roleID)
+		if err != nil {
+			return nil, err
+		}
+		return &GrantResult{Assignment: res}, nil
+	}
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", targetUserID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	var existingUR models.UserRole
+	err = s.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", targetUserID, roleID).First(&existingUR).Error
+	if err == nil {
+		res, err := assignSvc.Assign(ctx, actorID, targetUserID, roleID)
+		if err != nil {
+			return nil, err
+		}
+		return &GrantResult{Assignment: res}, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var pending models.RoleGrantRequest
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.expireStale(tx); err != nil {
+			return err
+		}
+		err := tx.Where("target_user_id = ? AND role_id = ? AND status = ?", targetUserID, roleID, models.GrantStatusPending).
+			First(&pending).Error
+		if err == nil {
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		pending = models.RoleGrantRequest{
+			TargetUserID:  targetUserID,
+			RoleID:        roleID,
+			RoleSlug:      role.Slug,
+			RequestedBy:   actorID,
+			RequestReason: reason,
+			Status:        models.GrantStatusPending,
+			ExpiresAt:     time.Now().Add(s.ttl),
+		}
+		if err := tx.Create(&pending).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      actorID,
+			Action:       models.AuditActionRequest,
+			TargetUserID: &targetUserID,
+			RoleID:       roleID,
+			RoleSlug:     role.Slug,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GrantResult{Pending: &pending}, nil
+}
+
+// List returns grant requests filtered by status (empty = all), newest first, paginated.
+// Stale pending requests are marked expired first.
+func (s *RoleGrantService) List(ctx context.Context, status string, page, limit int) ([]models.RoleGrantRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	switch status {
+	case "", models.GrantStatusPending, models.GrantStatusApproved, models.GrantStatusRejected, models.GrantStatusExpired:
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "pending, approved, rejected, expired")
+	}
+	if err := s.expireStale(s.db.WithContext(ctx)); err != nil {
+		return nil, 0, err
+	}
+	q := s.db.WithContext(ctx).Model(&models.RoleGrantRequest{})
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.RoleGrantRequest
+	if err := q.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Approve grants the requested role. The approver must differ from the requester (ErrSelfApproval);
+// the request must be pending (ErrGrantNotPending) and unexpired (ErrGrantExpired).
+func (s *RoleGrantService) Approve(ctx context.Context, approverID, requestID uuid.UUID, reason string) (*models.RoleGrantRequest, error) {
+	var req models.RoleGrantRequest
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := s.loadForDecision(tx, approverID, requestID, &req); err != nil {
+			return err
+		}
+		if _, err := NewUserRoleService(tx).Assign(ctx, approverID, req.TargetUserID, req.RoleID); err != nil {
+			return err
+		}
+		if err := s.decide(tx, &req, approverID, models.GrantStatusApproved, reason); err != nil {
+			return err
+		}
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      approverID,
+			Action:       models.AuditActionApprove,
+			TargetUserID: &req.TargetUserID,
+			RoleID:       req.RoleID,
+			RoleSlug:     req.RoleSlug,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// Reject closes the request without granting the role. A reason is required.
+func (s *RoleGrantService) Reject(ctx context.Context, approverID, requestID uuid.UUID, reason string) (*models.RoleGrantRequest, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.Invalid("reason", errors.MsgRequiredToReject)
+	}
+	var req models.RoleGrantRequest
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.loadForDecision(tx, approverID, requestID, &req); err != nil {
+			return err
+		}
+		if err := s.decide(tx, &req, approverID, models.GrantStatusRejected, reason); err != nil {
+			return err
+		}
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      approverID,
+			Action:       models.AuditActionReject,
+			TargetUserID: &req.TargetUserID,
+			RoleID:       req.RoleID,
+			RoleSlug:     req.RoleSlug,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// loadForDecision locks the request row and checks it can be decided by approverID.
+func (s *RoleGrantService) loadForDecision(tx *gorm.DB, approverID, requestID uuid.UUID, req *models.RoleGrantRequest) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", requestID).First(req).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrGrantNotFound
+		}
+		return err
+	}
+	if req.Status != models.GrantStatusPending {
+		return errors.ErrGrantNotPending
+	}
+	if time.Now().After(req.ExpiresAt) {
+		// Status is flipped to expired by the next expireStale sweep.
+		return errors.ErrGrantExpired
+	}
+	// Neither the requester nor the user receiving the role may decide the request.
+	if req.RequestedBy == approverID || req.TargetUserID == approverID {
+		return errors.ErrSelfApproval
+	}
+	return nil
+}
+
+func (s *RoleGrantService) decide(tx *gorm.DB, req *models.RoleGrantRequest, approverID uuid.UUID, status, reason string) error {
+	now := time.Now()
+	req.Status = status
+	req.DecidedBy = &approverID
+	req.DecidedAt = &now
+	req.DecisionReason = reason
+	return tx.Save(req).Error
+}
+
+// expireStale marks pending requests past their expiry as expired.
+func (s *RoleGrantService) expireStale(tx *gorm.DB) error {
+	return tx.Model(&models.RoleGrantRequest{}).
+		Where("status = ? AND expires_at < ?", models.GrantStatusPending, time.Now()).
+		Update("status", models.GrantStatusExpired).Error
+}
+
+// isSensitive returns true if the role is configured as sensitive or carries roles:manage.
+func (s *RoleGrantService) isSensitive(tx *gorm.DB, role *models.Role) (bool, error) {
+	if s.sensitive[role.Slug] {
+		return true, nil
+	}
+	return roleGrantsRolesManage(tx, role.ID, role.Slug)
+}
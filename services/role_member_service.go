@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxBatchMembers caps the number of add + remove items in one batch call.
+const maxBatchMembers = 1000
+
+// Batch item statuses.
+const (
+	BatchStatusAssigned  = "assigned"
+	BatchStatusPending   = "pending"
+	BatchStatusRemoved   = "removed"
+	BatchStatusUnchanged = "unchanged"
+	BatchStatusError     = "error"
+)
+
+// RoleMemberService lists a role's members and applies bulk add/remove.
+type RoleMemberService struct {
+	db *gorm.DB
+}
+
+// NewRoleMemberService returns a RoleMemberService.
+func NewRoleMemberService(db *gorm.DB) *RoleMemberService {
+	return &RoleMemberService{db: db}
+}
+
+// RoleMember is one user holding a role (for ListMembers).
+type RoleMember struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Username   string    `json:"username"`
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	AssignedAt string    `json:"assigned_at"` // ISO 8601
+}
+
+// ListMembers returns the users holding roleID, oldest assignment first, paginated.
+func (s *RoleMemberService) ListMembers(ctx context.Context, roleID uuid.UUID, page, limit int) ([]RoleMember, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	var role models.Role
+	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, 0, errors.ErrRoleNotFound
+		}
+		return nil, 0, err
+	}
+	q := s.db.WithContext(ctx).Table("user_roles").
+		Joins("JOIN users ON users.id = user_roles.user_id AND users.deleted_at IS NULL").
+		Where("user_roles.role_id = ?", roleID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	type row struct {
+		UserID    uuid.UUID
+		Username  string
+		Name      string
+		Email     string
+		CreatedAt time.Time
+	}
+	var rows []row
+	err := q.Select("users.id AS user_id, users.username, users.name, users.email, user_roles.created_at").
+		Order("user_roles.created_at ASC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]RoleMember, len(rows))
+	for i, r := range rows {
+		result[i] = RoleMember{
+			UserID:     r.UserID,
+			Username:   r.Username,
+			Name:       r.Name,
+			Email:      r.Email,
+			AssignedAt: r.CreatedAt.UTC().Format("2006-01-02T15:04:05.000Z"),
+		}
+	}
+	return result, total, nil
+}
+
+// BatchItemResult is the outcome for one user reference in a batch call.
+type BatchItemResult struct {
+	Ref    string     `json:"ref"` // user ID or email as sent
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Op     string     `json:"op"`     // add | remove
+	Status string     `json:"status"` // assigned | pending | removed | unchanged | error
+	Error  string     `json:"error,omitempty"`
+	Code   string     `json:"code,omitempty"`
+}
+
+// BatchError is returned in atomic mode when one item fails; the whole batch is rolled back.
+type BatchError struct {
+	Item BatchItemResult
+	Err  error
+}
+
+func (e *BatchError) Error() string { return e.Item.Ref + ": " + e.Err.Error() }
+
+// Unwrap exposes the item error so HTTPStatusAndCode maps the batch to the item's status.
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// Batch adds and removes members of roleID. refs are user UUIDs or emails.
+// In atomic mode (partial=false) everything runs in one transaction and the first failing item
+// aborts the batch with a *BatchError. In partial mode each item commits on its own and failures
+// are reported per item. Adds of sensitive roles become pending grants, as in POST /api/users/:id/roles.
+// Each change writes its own RoleAuditLog row.
+func (s *RoleMemberService) Batch(ctx context.Context, actorID, roleID uuid.UUID, add, remove []string, partial bool) ([]BatchItemResult, error) {
+	if len(add)+len(remove) == 0 {
+		return nil, errors.Invalid("add", errors.MsgOneRequired, "other", "remove")
+	}
+	if len(add)+len(remove) > maxBatchMembers {
+		return nil, errors.Invalid("add", errors.MsgMaxItems, "max", strconv.Itoa(maxBatchMembers))
+	}
+	var role models.Role
+	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	type op struct {
+		ref string
+		add bool
+	}
+	ops := make([]op, 0, len(add)+len(remove))
+	for _, r := range add {
+		ops = append(ops, op{ref: strings.TrimSpace(r), add: true})
+	}
+	for _, r := range remove {
+		ops = append(ops, op{ref: strings.TrimSpace(r), add: false})
+	}
+
+	apply := func(tx *gorm.DB, o op) (BatchItemResult, error) {
+		res := BatchItemResult{Ref: o.ref, Op: "remove"}
+		if o.add {
+			res.Op = "add"
+		}
+		uid, err := resolveUserRef(tx.WithContext(ctx), o.ref)
+		if err != nil {
+			return withItemError(res, err), err
+		}
+		res.UserID = &uid
+		if o.add {
+			grant, err := NewRoleGrantService(tx).Grant(ctx, actorID, uid, roleID, "")
+			if err != nil {
+				return withItemError(res, err), err
+			}
+			switch {
+			case grant.Pending != nil:
+				res.Status = BatchStatusPending
+			case grant.Assignment.Created:
+				res.Status = BatchStatusAssigned
+			default:
+				res.Status = BatchStatusUnchanged
+			}
+			return res, nil
+		}
+		err = NewUserRoleService(tx).Unassign(ctx, actorID, uid, roleID)
+		if stderrors.Is(err, errors.ErrAssignmentNotFound) {
+			res.Status = BatchStatusUnchanged
+			return res, nil
+		}
+		if err != nil {
+			return withItemError(res, err), err
+		}
+		res.Status = BatchStatusRemoved
+		return res, nil
+	}
+
+	results := make([]BatchItemResult, 0, len(ops))
+	if partial {
+		for _, o := range ops {
+			res, _ := apply(s.db, o)
+			results = append(results, res)
+		}
+		return results, nil
+	}
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		for _, o := range ops {
+			res, err := apply(tx, o)
+			if err != nil {
+				return &BatchError{Item: res, Err: err}
+			}
+			results = append(results, res)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// withItemError marks res as failed; internal errors are not echoed to the client.
+func withItemError(res BatchItemResult, err error) BatchItemResult {
+	status, code := errors.HTTPStatusAndCode(err)
+	res.Status = BatchStatusError
+	res.Code = code
+	res.Error = err.Error()
+	if status == 500 {
+		res.Error = "internal error"
+	}
+	return res
+}
+
+// resolveUserRef finds a non-deleted user by UUID or, if ref contains "@", by email.
+func resolveUserRef(tx *gorm.DB, ref string) (uuid.UUID, error) {
+	var user models.User
+	q := tx.Select("id")
+	if strings.Contains(ref, "@") {
+		q = q.Where("LOWER(email) = LOWER(?)", ref)
+	} else {
+		id, err := uuid.Parse(ref)
+		if err != nil {
+			return uuid.Nil, errors.Invalid("user", errors.MsgInvalidValue)
+		}
+		q = q.Where("id = ?", id)
+	}
+	if err := q.First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return uuid.Nil, errors.ErrUserNotFound
+		}
+		return uuid.Nil, err
+	}
+	return user.ID, nil
+}
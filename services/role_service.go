@@ -38,11 +38,15 @@ type RoleDTO struct {
 
 // Create creates a new role with the given permissions. Validates slug/name and permission keys.
 func (s *RoleService) Create(ctx context.Context, slug, name string, perms []string) (*RoleDTO, error) {
-	if slug == "" || name == "" || len(perms) == 0 {
-		return nil, errors.ErrValidation
-	}
-	if !slugRegex.MatchString(slug) {
-		return nil, errors.ErrValidation
+	switch {
+	case slug == "":
+		return nil, errors.Invalid("slug", errors.MsgRequired)
+	case name == "":
+		return nil, errors.Invalid("name", errors.MsgRequired)
+	case len(perms) == 0:
+		return nil, errors.Invalid("permissions", errors.MsgRequired)
+	case !slugRegex.MatchString(slug):
+		return nil, errors.Invalid("slug", errors.MsgInvalidValue)
 	}
 	for _, p := range perms {
 		if !permissions.IsValid(p) {
@@ -116,70 +120,102 @@ func (s *RoleService) List(ctx context.Context, page, limit int) ([]RoleDTO, int
 }
 
 // Update updates name and/or permissions. For system roles, permissions can only be added (superset).
+// Removing roles:manage is rejected with ErrRolesManageLockout if no user could manage roles afterwards.
 func (s *RoleService) Update(ctx context.Context, id uuid.UUID, name *string, perms []string) (*RoleDTO, error) {
-	var role models.Role
-	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&role).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrRoleNotFound
+	err := permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
 		}
-		return nil, err
-	}
-	if name != nil {
-		var existing models.Role
-		if err := s.db.WithContext(ctx).Where("name = ? AND id != ?", *name, id).First(&existing).Error; err == nil {
-			return nil, errors.ErrRoleNameConflict
+		var role models.Role
+		if err := tx.Where("id = ?", id).First(&role).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRoleNotFound
+			}
+			return err
 		}
-		role.Name = *name
-		if err := s.db.WithContext(ctx).Save(&role).Error; err != nil {
-			return nil, err
+		if name != nil {
+			var existing models.Role
+			if err := tx.Where("name = ? AND id != ?", *name, id).First(&existing).Error; err == nil {
+				return errors.ErrRoleNameConflict
+			}
+			role.Name = *name
+			if err := tx.Save(&role).Error; err != nil {
+				return err
+			}
+		}
+		if perms == nil {
+			return nil
 		}
-	}
-	if perms != nil {
 		for _, p := range perms {
 			if !permissions.IsValid(p) {
-				return nil, errors.ErrPermissionInvalid
+				return errors.ErrPermissionInvalid
 			}
 		}
+		var current []string
+		if err := tx.Model(&models.RolePermission{}).Where("role_id = ?", id).Pluck("permission", &current).Error; err != nil {
+			return err
+		}
+		permSet := make(map[string]bool)
+		for _, p := range perms {
+			permSet[p] = true
+		}
 		if role.IsSystem {
-			var current []string
-			if err := s.db.WithContext(ctx).Model(&models.RolePermission{}).Where("role_id = ?", id).Pluck("permission", &current).Error; err != nil {
-				return nil, err
-			}
-			permSet := make(map[string]bool)
-			for _, p := range perms {
-				permSet[p] = true
-			}
 			for _, c := range current {
 				if !permSet[c] {
-					return nil, errors.ErrSystemRoleProtected
+					return errors.ErrSystemRoleProtected
 				}
 			}
 		}
-		if err := s.db.WithContext(ctx).Where("role_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
-			return nil, err
+		hadManage := false
+		for _, c := range current {
+			if c == permissions.RolesManage {
+				hadManage = true
+			}
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
 		}
 		for _, p := range perms {
-			if err := s.db.WithContext(ctx).Create(&models.RolePermission{RoleID: id, Permission: p}).Error; err != nil {
-				return nil, err
+			if err := tx.Create(&models.RolePermission{RoleID: id, Permission: p}).Error; err != nil {
+				return err
 			}
 		}
+		invalidateAllPermissions(tx)
+		return checkRBACInvariants(tx, false, hadManage && !permSet[permissions.RolesManage])
+	})
+	if err != nil {
+		return nil, err
 	}
 	return s.GetByID(ctx, id)
 }
 
-// Delete soft-deletes a role. Rejects system roles.
+// Delete soft-deletes a role. Rejects system roles, and roles whose removal would leave
+// nobody able to manage roles (ErrRolesManageLockout).
 func (s *RoleService) Delete(ctx context.Context, id uuid.UUID) error {
-	var role models.Role
-	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&role).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.ErrRoleNotFound
+	return permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
 		}
-		return err
-	}
-	if role.IsSystem {
-		return errors.ErrSystemRoleProtected
-	}
-	return s.db.WithContext(ctx).Delete(&role).Error
+		var role models.Role
+		if err := tx.Where("id = ?", id).First(&role).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRoleNotFound
+			}
+			return err
+		}
+		if role.IsSystem {
+			return errors.ErrSystemRoleProtected
+		}
+		managing, err := roleGrantsRolesManage(tx, role.ID, role.Slug)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&role).Error; err != nil {
+			return err
+		}
+		invalidateAllPermissions(tx)
+		return checkRBACInvariants(tx, false, managing)
+	})
 }
 
 func (s *RoleService) getRoleDTO(ctx context.Context, role *models.Role, perms []string) (*RoleDTO, error) {
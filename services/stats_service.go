@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// statsLockKey keeps concurrent instances from refreshing the rollups at the same time.
+	statsLockKey                = 727_002
+	defaultStatsRefreshInterval = 15 * time.Minute
+	// rollupOverlapDays is how many already-rolled-up days each refresh recomputes, so late rows
+	// (and the still-running current day) are picked up.
+	rollupOverlapDays = 2
+	maxStatsRangeDays = 366
+	statsDayLayout    = "2006-01-02"
+)
+
+// Stats buckets.
+const (
+	StatsBucketDay  = "day"
+	StatsBucketWeek = "week"
+)
+
+// StatsService serves the admin dashboard from rollup tables refreshed by RunStatsWorker.
+type StatsService struct {
+	db *gorm.DB
+}
+
+// NewStatsService returns a StatsService.
+func NewStatsService(db *gorm.DB) *StatsService {
+	return &StatsService{db: db}
+}
+
+// StatPoint is one bucket of a series. By splits Value by dimension (place type, visibility).
+type StatPoint struct {
+	Bucket string           `json:"bucket"` // YYYY-MM-DD (start of week for weekly buckets)
+	Value  int64            `json:"value"`
+	By     map[string]int64 `json:"by,omitempty"`
+}
+
+// PlaceStat is the current number of places of one type and verification status.
+type PlaceStat struct {
+	Type     string `json:"type"`
+	Verified bool   `json:"verified"`
+	Count    int64  `json:"count"`
+}
+
+// StatsTotals sums the series over the window.
+type StatsTotals struct {
+	Signups       int64   `json:"signups"`
+	ActiveUsers   int64   `json:"active_users"` // distinct users active in the window
+	PlacesCreated int64   `json:"places_created"`
+	PlansCreated  int64   `json:"plans_created"`
+	PlansPublic   int64   `json:"plans_public"`
+	PlansPrivate  int64   `json:"plans_private"`
+	PublicRatio   float64 `json:"public_ratio"` // public / plans_created, 0 when no plans
+}
+
+// AdminStats is the response shape of GET /admin/stats.
+type AdminStats struct {
+	From          string           `json:"from"`
+	To            string           `json:"to"`
+	Bucket        string           `json:"bucket"`
+	Signups       []StatPoint      `json:"signups"`
+	ActiveUsers   []StatPoint      `json:"active_users"`
+	PlacesCreated []StatPoint      `json:"places_created"`
+	PlansCreated  []StatPoint      `json:"plans_created"`
+	Totals        StatsTotals      `json:"totals"`
+	Places        []PlaceStat      `json:"places"`
+	RoleMembers   map[string]int64 `json:"role_members"`
+	Users         map[string]int64 `json:"users"`
+	RefreshedAt   *time.Time       `json:"refreshed_at"`
+}
+
+// Stats returns the dashboard aggregates for the inclusive [from, to] window (UTC dates).
+func (s *StatsService) Stats(ctx context.Context, from, to time.Time, bucket string) (*AdminStats, error) {
+	if bucket != StatsBucketDay && bucket != StatsBucketWeek {
+		return nil, errors.Invalid("bucket", errors.MsgOneOf, "values", "day, week")
+	}
+	if to.Before(from) {
+		return nil, errors.Invalid("from", errors.MsgNotAfter, "other", "to")
+	}
+	if to.Sub(from) > maxStatsRangeDays*24*time.Hour {
+		return nil, errors.Invalid("to", errors.MsgMaxDays, "max", strconv.Itoa(maxStatsRangeDays))
+	}
+	out := &AdminStats{
+		From:        from.Format(statsDayLayout),
+		To:          to.Format(statsDayLayout),
+		Bucket:      bucket,
+		RoleMembers: map[string]int64{},
+		Users:       map[string]int64{},
+	}
+	buckets := statBuckets(from, to, bucket)
+	var err error
+	if out.Signups, err = s.rollupSeries(ctx, models.StatSignups, from, to, bucket, buckets); err != nil {
+		return nil, err
+	}
+	if out.PlacesCreated, err = s.rollupSeries(ctx, models.StatPlacesCreated, from, to, bucket, buckets); err != nil {
+		return nil, err
+	}
+	if out.PlansCreated, err = s.rollupSeries(ctx, models.StatPlansCreated, from, to, bucket, buckets); err != nil {
+		return nil, err
+	}
+	if out.ActiveUsers, err = s.activeSeries(ctx, from, to, bucket, buckets); err != nil {
+		return nil, err
+	}
+
+	for _, p := range out.Signups {
+		out.Totals.Signups += p.Value
+	}
+	for _, p := range out.PlacesCreated {
+		out.Totals.PlacesCreated += p.Value
+	}
+	for _, p := range out.PlansCreated {
+		out.Totals.PlansCreated += p.Value
+		out.Totals.PlansPublic += p.By[string(models.VisibilityPublic)]
+		out.Totals.PlansPrivate += p.By[string(models.VisibilityPrivate)]
+	}
+	if out.Totals.PlansCreated > 0 {
+		out.Totals.PublicRatio = float64(out.Totals.PlansPublic) / float64(out.Totals.PlansCreated)
+	}
+	if err := s.db.WithContext(ctx).Model(&models.UserActivityDay{}).
+		Where("day BETWEEN ? AND ?", from, to).
+		Select("COUNT(DISTINCT user_id)").Scan(&out.Totals.ActiveUsers).Error; err != nil {
+		return nil, err
+	}
+
+	var snaps []models.StatSnapshot
+	if err := s.db.WithContext(ctx).Order("metric, dimension").Find(&snaps).Error; err != nil {
+		return nil, err
+	}
+	out.Places = []PlaceStat{}
+	for _, sn := range snaps {
+		switch sn.Metric {
+		case models.StatPlaces:
+			typ, status := sn.Dimension, ""
+			if i := strings.LastIndexByte(sn.Dimension, ':'); i >= 0 {
+				typ, status = sn.Dimension[:i], sn.Dimension[i+1:]
+			}
+			out.Places = append(out.Places, PlaceStat{Type: typ, Verified: status == "verified", Count: sn.Value})
+		case models.StatRoleMembers:
+			out.RoleMembers[sn.Dimension] = sn.Value
+		case models.StatUsers:
+			out.Users[sn.Dimension] = sn.Value
+		}
+		if out.RefreshedAt == nil || sn.RefreshedAt.After(*out.RefreshedAt) {
+			t := sn.RefreshedAt
+			out.RefreshedAt = &t
+		}
+	}
+	return out, nil
+}
+
+// Refresh rebuilds the daily rollups for the last few days (everything on the first run) and
+// replaces the snapshots. Returns without doing anything if another instance is refreshing.
+func (s *StatsService) Refresh(ctx context.Context) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", statsLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		var last *time.Time
+		if err := tx.Model(&models.StatRollup{}).Select("MAX(day)").Scan(&last).Error; err != nil {
+			return err
+		}
+		since := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+		if last != nil {
+			since = last.AddDate(0, 0, -rollupOverlapDays)
+		}
+		if err := tx.Where("day >= ?", since).Delete(&models.StatRollup{}).Error; err != nil {
+			return err
+		}
+		rollups := []string{
+			`INSERT INTO stat_rollups (day, metric, dimension, value)
+			 SELECT created_at::date, 'signups', '', COUNT(*) FROM users
+			 WHERE created_at >= ? GROUP BY 1`,
+			`INSERT INTO stat_rollups (day, metric, dimension, value)
+			 SELECT p.created_at::date, 'places_created', COALESCE(pt.slug, ''), COUNT(*) FROM places p
+			 LEFT JOIN place_types pt ON pt.id = p.place_type_id
+			 WHERE p.created_at >= ? GROUP BY 1, 3`,
+			`INSERT INTO stat_rollups (day, metric, dimension, value)
+			 SELECT created_at::date, 'plans_created', COALESCE(visibility, ''), COUNT(*) FROM plans
+			 WHERE created_at >= ? GROUP BY 1, 3`,
+		}
+		for _, q := range rollups {
+			if err := tx.Exec(q, since).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("1 = 1").Delete(&models.StatSnapshot{}).Error; err != nil {
+			return err
+		}
+		snapshots := []string{
+			`INSERT INTO stat_snapshots (metric, dimension, value, refreshed_at)
+			 SELECT 'places', COALESCE(pt.slug, '') || ':' || CASE WHEN p.is_verified THEN 'verified' ELSE 'unverified' END,
+			        COUNT(*), NOW() FROM places p
+			 LEFT JOIN place_types pt ON pt.id = p.place_type_id
+			 WHERE p.deleted_at IS NULL GROUP BY 2`,
+			`INSERT INTO stat_snapshots (metric, dimension, value, refreshed_at)
+			 SELECT 'role_members', r.slug, COUNT(ur.user_id), NOW() FROM roles r
+			 LEFT JOIN user_roles ur ON ur.role_id = r.id
+			 WHERE r.deleted_at IS NULL GROUP BY r.slug`,
+			`INSERT INTO stat_snapshots (metric, dimension, value, refreshed_at)
+			 SELECT 'users', CASE
+			          WHEN u.deleted_at IS NOT NULL THEN 'deleted'
+			          WHEN u.suspended_at IS NOT NULL AND (u.suspended_until IS NULL OR u.suspended_until > NOW()) THEN 'suspended'
+			          ELSE 'active' END,
+			        COUNT(*), NOW() FROM users u GROUP BY 2`,
+		}
+		for _, q := range snapshots {
+			if err := tx.Exec(q).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RunStatsWorker refreshes the dashboard rollups every STATS_REFRESH_INTERVAL (default 15m)
+// until ctx is done.
+func RunStatsWorker(ctx context.Context, db *gorm.DB) {
+	interval := defaultStatsRefreshInterval
+	if d, err := time.ParseDuration(os.Getenv("STATS_REFRESH_INTERVAL")); err == nil && d > 0 {
+		interval = d
+	}
+	s := NewStatsService(db)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			log.Printf("stats worker: refresh: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// activitySeen holds the users whose activity this instance already recorded today, so
+// RecordActivity writes at most once per user per day. It is emptied when the day changes.
+var activitySeen struct {
+	sync.Mutex
+	day   string
+	users map[uuid.UUID]struct{}
+}
+
+// RecordActivity marks the user as active today (UTC) for the active-users statistic.
+func RecordActivity(db *gorm.DB, userID uuid.UUID) {
+	day := time.Now().UTC().Format(statsDayLayout)
+	activitySeen.Lock()
+	if activitySeen.day != day {
+		activitySeen.day, activitySeen.users = day, map[uuid.UUID]struct{}{}
+	}
+	_, seen := activitySeen.users[userID]
+	activitySeen.Unlock()
+	if seen {
+		return
+	}
+	if err := db.Exec(
+		"INSERT INTO user_activity_days (user_id, day) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, day,
+	).Error; err != nil {
+		log.Printf("stats: record activity: %v", err)
+		return
+	}
+	activitySeen.Lock()
+	if activitySeen.day == day {
+		activitySeen.users[userID] = struct{}{}
+	}
+	activitySeen.Unlock()
+}
+
+func (s *StatsService) rollupSeries(ctx context.Context, metric string, from, to time.Time, bucket string, buckets []string) ([]StatPoint, error) {
+	type row struct {
+		Bucket    time.Time
+		Dimension string
+		Value     int64
+	}
+	var rows []row
+	err := s.db.WithContext(ctx).Raw(
+		`SELECT date_trunc(?, day)::date AS bucket, dimension, SUM(value) AS value FROM stat_rollups
+		 WHERE metric = ? AND day BETWEEN ? AND ? GROUP BY 1, 2`,
+		bucket, metric, from, to,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	points, index := emptySeries(buckets)
+	for _, r := range rows {
+		p := index[r.Bucket.Format(statsDayLayout)]
+		if p == nil {
+			continue
+		}
+		p.Value += r.Value
+		if r.Dimension != "" {
+			if p.By == nil {
+				p.By = map[string]int64{}
+			}
+			p.By[r.Dimension] += r.Value
+		}
+	}
+	return points, nil
+}
+
+func (s *StatsService) activeSeries(ctx context.Context, from, to time.Time, bucket string, buckets []string) ([]StatPoint, error) {
+	type row struct {
+		Bucket time.Time
+		Value  int64
+	}
+	var rows []row
+	err := s.db.WithContext(ctx).Raw(
+		`SELECT date_trunc(?, day)::date AS bucket, COUNT(DISTINCT user_id) AS value FROM user_activity_days
+		 WHERE day BETWEEN ? AND ? GROUP BY 1`,
+		bucket, from, to,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	points, index := emptySeries(buckets)
+	for _, r := range rows {
+		if p := index[r.Bucket.Format(statsDayLayout)]; p != nil {
+			p.Value = r.Value
+		}
+	}
+	return points, nil
+}
+
+// statBuckets lists the bucket keys covering [from, to]; weekly buckets start on Monday
+// (as Postgres date_trunc('week') does).
+func statBuckets(from, to time.Time, bucket string) []string {
+	start := from
+	step := 1
+	if bucket == StatsBucketWeek {
+		offset := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -offset)
+		step = 7
+	}
+	var out []string
+	for d := start; !d.After(to); d = d.AddDate(0, 0, step) {
+		out = append(out, d.Format(statsDayLayout))
+	}
+	return out
+}
+
+func emptySeries(buckets []string) ([]StatPoint, map[string]*StatPoint) {
+	points := make([]StatPoint, len(buckets))
+	index := make(map[string]*StatPoint, len(buckets))
+	for i, b := range buckets {
+		points[i] = StatPoint{Bucket: b}
+		index[b] = &points[i]
+	}
+	return points, index
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxTranslationLength caps a translated value (place descriptions are the longest source).
+const maxTranslationLength = 10000
+
+// TranslationSet maps locale → field → translated value.
+type TranslationSet map[string]map[string]string
+
+// MissingTranslation is a translatable field with a source value but no translation in a locale.
+type MissingTranslation struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Field      string    `json:"field"`
+	Source     string    `json:"source"`
+}
+
+// TranslationService stores per-locale translations of places and place types and applies them
+// to read responses following the locale fallback chain (see NegotiateLocales). Untranslated
+// fields keep their stored value.
+type TranslationService struct {
+	db *gorm.DB
+}
+
+// NewTranslationService returns a TranslationService.
+func NewTranslationService(db *gorm.DB) *TranslationService {
+	return &TranslationService{db: db}
+}
+
+// Get returns every translation of the entity.
+func (s *TranslationService) Get(ctx context.Context, entityType string, entityID uuid.UUID) (TranslationSet, error) {
+	if _, err := s.sourceFields(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+	var rows []models.Translation
+	if err := s.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ?", entityType, entityID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	set := TranslationSet{}
+	for _, t := range rows {
+		if set[t.Locale] == nil {
+			set[t.Locale] = map[string]string{}
+		}
+		set[t.Locale][t.Field] = t.Value
+	}
+	return set, nil
+}
+
+// Set stores the given translations of the entity; an empty value removes that translation.
+// Locales must be supported and fields translatable for the entity. Returns every translation.
+func (s *TranslationService) Set(ctx context.Context, actorID *uuid.UUID, entityType string, entityID uuid.UUID, set TranslationSet) (TranslationSet, error) {
+	if len(set) == 0 {
+		return nil, errors.Invalid("translations", errors.MsgNotEmpty)
+	}
+	fields, err := s.sourceFields(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	for locale, values := range set {
+		if !IsSupportedLocale(locale) {
+			return nil, errors.Invalid("locale", errors.MsgOneOf, "values", strings.Join(supportedLocales, ", "))
+		}
+		for field, value := range values {
+			if _, ok := fields[field]; !ok {
+				return nil, errors.Invalid(locale+"."+field, errors.MsgNotTranslatable)
+			}
+			if len(value) > maxTranslationLength {
+				return nil, errors.Invalid(locale+"."+field, errors.MsgMaxLength, "max", strconv.Itoa(maxTranslationLength))
+			}
+			if err := checkBannedWords(locale+"."+field, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for locale, values := range set {
+			for field, value := range values {
+				key := tx.Where("entity_type = ? AND entity_id = ? AND field = ? AND locale = ?", entityType, entityID, field, locale)
+				if strings.TrimSpace(value) == "" {
+					if err := key.Delete(&models.Translation{}).Error; err != nil {
+						return err
+					}
+					continue
+				}
+				t := models.Translation{EntityType: entityType, EntityID: entityID, Field: field, Locale: locale, Value: value, UpdatedBy: actorID}
+				if err := tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "field"}, {Name: "locale"}},
+					DoUpdates: clause.Assignments(map[string]interface{}{"value": value, "updated_by": actorID, "updated_at": time.Now()}),
+				}).Create(&t).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, entityType, entityID)
+}
+
+// LocalizePlaces replaces the name and description of the places (and their preloaded place
+// types) with the best translation for the locale chain.
+func (s *TranslationService) LocalizePlaces(ctx context.Context, locales []string, places ...*models.Place) error {
+	ids := make([]uuid.UUID, len(places))
+	var types []*models.PlaceType
+	for i, p := range places {
+		ids[i] = p.ID
+		if p.PlaceType != nil {
+			types = append(types, p.PlaceType)
+		}
+	}
+	best, err := s.best(ctx, models.TranslationPlace, ids, locales)
+	if err != nil {
+		return err
+	}
+	for _, p := range places {
+		if v, ok := best[p.ID]["name"]; ok {
+			p.Name = v
+		}
+		if v, ok := best[p.ID]["description"]; ok {
+			p.Description = v
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	return s.LocalizePlaceTypes(ctx, locales, types...)
+}
+
+// LocalizePlaceTypes replaces the name and form field labels of the place types with the best
+// translation for the locale chain.
+func (s *TranslationService) LocalizePlaceTypes(ctx context.Context, locales []string, types ...*models.PlaceType) error {
+	ids := make([]uuid.UUID, len(types))
+	for i, pt := range types {
+		ids[i] = pt.ID
+	}
+	best, err := s.best(ctx, models.TranslationPlaceType, ids, locales)
+	if err != nil {
+		return err
+	}
+	for _, pt := range types {
+		values := best[pt.ID]
+		if v, ok := values["name"]; ok {
+			pt.Name = v
+		}
+		fields, _ := pt.FormSchema["fields"].([]interface{})
+		for _, raw := range fields {
+			field, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := field["key"].(string)
+			if v, ok := values["form."+key]; ok && key != "" {
+				field["label"] = v
+			}
+		}
+	}
+	return nil
+}
+
+// Missing lists translatable fields of the entity type that have a source value but no
+// translation in the locale, oldest entity first.
+func (s *TranslationService) Missing(ctx context.Context, locale, entityType string, page, limit int) ([]MissingTranslation, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if !IsSupportedLocale(locale) {
+		return nil, 0, errors.Invalid("locale", errors.MsgOneOf, "values", strings.Join(supportedLocales, ", "))
+	}
+	switch entityType {
+	case models.TranslationPlace:
+		const missing = `FROM places p
+			CROSS JOIN LATERAL (VALUES ('name', p.name), ('description', p.description)) f(field, source)
+			WHERE p.deleted_at IS NULL AND f.source <> ''
+			  AND NOT EXISTS (SELECT 1 FROM translations t WHERE t.entity_type = ? AND t.entity_id = p.id
+			                  AND t.field = f.field AND t.locale = ?)`
+		var total int64
+		if err := s.db.WithContext(ctx).Raw("SELECT COUNT(*) "+missing, entityType, locale).Scan(&total).Error; err != nil {
+			return nil, 0, err
+		}
+		list := []MissingTranslation{}
+		err := s.db.WithContext(ctx).Raw("SELECT ? AS entity_type, p.id AS entity_id, f.field, f.source "+missing+
+			" ORDER BY p.created_at, p.id, f.field LIMIT ? OFFSET ?",
+			entityType, entityType, locale, limit, (page-1)*limit).Scan(&list).Error
+		if err != nil {
+			return nil, 0, err
+		}
+		return list, total, nil
+	case models.TranslationPlaceType:
+		// Few place types: collect in memory.
+		var types []models.PlaceType
+		if err := s.db.WithContext(ctx).Order("created_at, id").Find(&types).Error; err != nil {
+			return nil, 0, err
+		}
+		var have []models.Translation
+		if err := s.db.WithContext(ctx).Where("entity_type = ? AND locale = ?", entityType, locale).Find(&have).Error; err != nil {
+			return nil, 0, err
+		}
+		done := map[string]bool{}
+		for _, t := range have {
+			done[t.EntityID.String()+"/"+t.Field] = true
+		}
+		all := []MissingTranslation{}
+		for i := range types {
+			fields := placeTypeSourceFields(&types[i])
+			names := make([]string, 0, len(fields))
+			for f := range fields {
+				names = append(names, f)
+			}
+			sort.Strings(names)
+			for _, f := range names {
+				if fields[f] != "" && !done[types[i].ID.String()+"/"+f] {
+					all = append(all, MissingTranslation{EntityType: entityType, EntityID: types[i].ID, Field: f, Source: fields[f]})
+				}
+			}
+		}
+		total := int64(len(all))
+		start := (page - 1) * limit
+		if start > len(all) {
+			start = len(all)
+		}
+		end := start + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], total, nil
+	default:
+		return nil, 0, errors.Invalid("entity_type", errors.MsgOneOf, "values", "place, place_type")
+	}
+}
+
+// best returns, per entity and field, the translation ranked first in the locale chain.
+func (s *TranslationService) best(ctx context.Context, entityType string, ids []uuid.UUID, locales []string) (map[uuid.UUID]map[string]string, error) {
+	out := map[uuid.UUID]map[string]string{}
+	if len(ids) == 0 || len(locales) == 0 {
+		return out, nil
+	}
+	var rows []models.Translation
+	if err := s.db.WithContext(ctx).Where("entity_type = ? AND entity_id IN ? AND locale IN ?", entityType, ids, locales).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	rank := map[string]int{}
+	for i, l := range locales {
+		rank[l] = i
+	}
+	chosen := map[uuid.UUID]map[string]int{}
+	for _, t := range rows {
+		if out[t.EntityID] == nil {
+			out[t.EntityID], chosen[t.EntityID] = map[string]string{}, map[string]int{}
+		}
+		if r, ok := chosen[t.EntityID][t.Field]; ok && r <= rank[t.Locale] {
+			continue
+		}
+		out[t.EntityID][t.Field] = t.Value
+		chosen[t.EntityID][t.Field] = rank[t.Locale]
+	}
+	return out, nil
+}
+
+// sourceFields returns the translatable fields of the entity with their stored values.
+func (s *TranslationService) sourceFields(ctx context.Context, entityType string, entityID uuid.UUID) (map[string]string, error) {
+	switch entityType {
+	case models.TranslationPlace:
+		var p models.Place
+		if err := s.db.WithContext(ctx).Where("id = ?", entityID).First(&p).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.ErrPlaceNotFound
+			}
+			return nil, err
+		}
+		return map[string]string{"name": p.Name, "description": p.Description}, nil
+	case models.TranslationPlaceType:
+		var pt models.PlaceType
+		if err := s.db.WithContext(ctx).Where("id = ?", entityID).First(&pt).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.ErrPlaceTypeNotFound
+			}
+			return nil, err
+		}
+		return placeTypeSourceFields(&pt), nil
+	default:
+		return nil, errors.Invalid("entity_type", errors.MsgOneOf, "values", "place, place_type")
+	}
+}
+
+// placeTypeSourceFields returns the name and the form field labels ("form.<key>") of a place type.
+func placeTypeSourceFields(pt *models.PlaceType) map[string]string {
+	fields := map[string]string{"name": pt.Name}
+	for _, f := range FormFields(pt.FormSchema) {
+		fields["form."+f.Key] = f.Label
+	}
+	return fields
+}
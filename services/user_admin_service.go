@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"ducksrow/backend/errors"
+	"ducksrow/backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User status filters for List.
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusDeleted   = "deleted"
+)
+
+// UserAdminService lists, inspects, suspends and soft-deletes users for administrators.
+type UserAdminService struct {
+	db *gorm.DB
+}
+
+// NewUserAdminService returns a UserAdminService.
+func NewUserAdminService(db *gorm.DB) *UserAdminService {
+	return &UserAdminService{db: db}
+}
+
+// UserListFilter narrows List. Query matches email, username, name and name_local (case-insensitive).
+type UserListFilter struct {
+	Query  string
+	Role   string // role slug
+	Status string // active | suspended | deleted; empty = active and suspended
+	Page   int
+	Limit  int
+}
+
+// UserSummary is one row of List.
+type UserSummary struct {
+	models.User
+	Status    string     `json:"status"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Roles     []string   `json:"roles"`
+}
+
+// UserDetail is the response shape of Get.
+type UserDetail struct {
+	UserSummary
+	RoleAssignments []UserRoleListItem `json:"role_assignments"`
+	PlacesCount     int64              `json:"places_count"`
+	PlansCount      int64              `json:"plans_count"`
+}
+
+// List returns users matching f, newest first, with their role slugs.
+func (s *UserAdminService) List(ctx context.Context, f UserListFilter) ([]UserSummary, int64, error) {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 {
+		f.Limit = 20
+	}
+	if f.Limit > 100 {
+		f.Limit = 100
+	}
+	q := s.db.WithContext(ctx).Model(&models.User{})
+	switch f.Status {
+	case "":
+	case UserStatusActive:
+		q = q.Where("suspended_at IS NULL OR (suspended_until IS NOT NULL AND suspended_until <= NOW())")
+	case UserStatusSuspended:
+		q = q.Where("suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW())")
+	case UserStatusDeleted:
+		q = q.Unscoped().Where("deleted_at IS NOT NULL")
+	default:
+		return nil, 0, errors.Invalid("status", errors.MsgOneOf, "values", "active, suspended, deleted")
+	}
+	if term := strings.TrimSpace(f.Query); term != "" {
+		like := "%" + strings.ToLower(term) + "%"
+		q = q.Where("LOWER(email) LIKE ? OR LOWER(username) LIKE ? OR LOWER(name) LIKE ? OR LOWER(name_local) LIKE ?",
+			like, like, like, like)
+	}
+	if f.Role != "" {
+		q = q.Where(`id IN (SELECT ur.user_id FROM user_roles ur
+			JOIN roles r ON r.id = ur.role_id AND r.deleted_at IS NULL WHERE r.slug = ?)`, f.Role)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []models.User
+	if err := q.Order("created_at DESC").Offset((f.Page - 1) * f.Limit).Limit(f.Limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	ids := make([]uuid.UUID, len(users))
+	for i := range users {
+		ids[i] = users[i].ID
+	}
+	roles, err := s.roleSlugsByUser(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]UserSummary, len(users))
+	for i := range users {
+		result[i] = summarize(&users[i], roles[users[i].ID])
+	}
+	return result, total, nil
+}
+
+// Get returns one user (including soft-deleted) with role assignments and place / plan counts.
+func (s *UserAdminService) Get(ctx context.Context, id uuid.UUID) (*UserDetail, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	assignments, err := s.roleAssignments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make([]string, len(assignments))
+	for i, a := range assignments {
+		slugs[i] = a.Slug
+	}
+	d := &UserDetail{UserSummary: summarize(&user, slugs), RoleAssignments: assignments}
+	if err := s.db.WithContext(ctx).Model(&models.Place{}).Where("owner_id = ?", id).Count(&d.PlacesCount).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).Model(&models.Plan{}).Where("creator_id = ?", id).Count(&d.PlansCount).Error; err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Suspend blocks the user from authenticating until `until` (nil = indefinitely).
+// Rejects self-suspension, suspending an admin unless the actor is one, and suspending the last
+// active admin / role manager.
+func (s *UserAdminService) Suspend(ctx context.Context, actorID, userID uuid.UUID, reason string, until *time.Time) error {
+	if actorID == userID {
+		return errors.Msg(errors.ErrValidation, errors.MsgSelfTarget)
+	}
+	if until != nil && !until.After(time.Now()) {
+		return errors.Invalid("until", errors.MsgPastDate)
+	}
+	return s.mutate(ctx, actorID, userID, false, models.AuditActionSuspend, reason, func(tx *gorm.DB, u *models.User) error {
+		now := time.Now()
+		return tx.Model(u).Updates(map[string]interface{}{
+			"suspended_at":      now,
+			"suspended_until":   until,
+			"suspension_reason": reason,
+		}).Error
+	})
+}
+
+// Unsuspend lifts a suspension.
+func (s *UserAdminService) Unsuspend(ctx context.Context, actorID, userID uuid.UUID) error {
+	return s.mutate(ctx, actorID, userID, false, models.AuditActionUnsuspend, "", func(tx *gorm.DB, u *models.User) error {
+		return tx.Model(u).Updates(map[string]interface{}{
+			"suspended_at":      nil,
+			"suspended_until":   nil,
+			"suspension_reason": "",
+		}).Error
+	})
+}
+
+// Delete soft-deletes the user (sets DeletedAt). Rejects self-deletion, deleting an admin unless the
+// actor is one, and deleting the last active admin.
+func (s *UserAdminService) Delete(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return errors.Msg(errors.ErrValidation, errors.MsgSelfTarget)
+	}
+	return s.mutate(ctx, actorID, userID, false, models.AuditActionUserDelete, "", func(tx *gorm.DB, u *models.User) error {
+		return tx.Delete(u).Error
+	})
+}
+
+// UserUpdateInput is the body of PATCH /api/users/:id; nil fields are left unchanged.
+type UserUpdateInput struct {
+	City *string `json:"city"`
+}
+
+// Update changes the admin-managed attributes of a user (used by access policies).
+func (s *UserAdminService) Update(ctx context.Context, actorID, userID uuid.UUID, in UserUpdateInput) error {
+	if in.City == nil {
+		return errors.Invalid("city", errors.MsgRequired)
+	}
+	city := strings.TrimSpace(*in.City)
+	if len(city) > 100 {
+		return errors.Invalid("city", errors.MsgTooLarge)
+	}
+	return s.mutate(ctx, actorID, userID, false, models.AuditActionUserUpdate, "city: "+city, func(tx *gorm.DB, u *models.User) error {
+		return tx.Model(u).Update("city", city).Error
+	})
+}
+
+// Restore clears DeletedAt on a soft-deleted user.
+func (s *UserAdminService) Restore(ctx context.Context, actorID, userID uuid.UUID) error {
+	return s.mutate(ctx, actorID, userID, true, models.AuditActionUserRestore, "", func(tx *gorm.DB, u *models.User) error {
+		return tx.Unscoped().Model(u).Update("deleted_at", nil).Error
+	})
+}
+
+// mutate loads the user (deleted ones only when deleted is true), applies fn, re-checks the
+// admin / roles:manage invariants and writes an audit entry, all in one transaction. Only admins
+// may change an admin's account.
+func (s *UserAdminService) mutate(ctx context.Context, actorID, userID uuid.UUID, deleted bool, action, detail string, fn func(tx *gorm.DB, u *models.User) error) error {
+	return permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
+		}
+		var user models.User
+		q := tx.Where("id = ?", userID)
+		if deleted {
+			q = tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", userID)
+		}
+		if err := q.First(&user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrUserNotFound
+			}
+			return err
+		}
+		targetAdmin, err := isAdminUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if targetAdmin {
+			actorAdmin, err := isAdminUser(tx, actorID)
+			if err != nil {
+				return err
+			}
+			if !actorAdmin {
+				return errors.Msg(errors.ErrForbidden, errors.MsgAdminTarget)
+			}
+		}
+		if err := fn(tx, &user); err != nil {
+			return err
+		}
+		managing, err := canManageRoles(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkRBACInvariants(tx, targetAdmin, managing); err != nil {
+			return err
+		}
+		invalidateUserPermissions(tx, userID)
+		return tx.Create(&models.RoleAuditLog{
+			ActorID:      actorID,
+			Action:       action,
+			TargetUserID: &userID,
+			Detail:       detail,
+		}).Error
+	})
+}
+
+func summarize(u *models.User, roles []string) UserSummary {
+	sum := UserSummary{User: *u, Status: UserStatusActive, Roles: roles}
+	if sum.Roles == nil {
+		sum.Roles = []string{}
+	}
+	if u.IsSuspended(time.Now()) {
+		sum.Status = UserStatusSuspended
+	}
+	if u.DeletedAt.Valid {
+		t := u.DeletedAt.Time
+		sum.DeletedAt = &t
+		sum.Status = UserStatusDeleted
+	}
+	return sum
+}
+
+func (s *UserAdminService) roleSlugsByUser(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]string, error) {
+	out := make(map[uuid.UUID][]string, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	type row struct {
+		UserID uuid.UUID
+		Slug   string
+	}
+	var rows []row
+	err := s.db.WithContext(ctx).Table("user_roles").
+		Select("user_roles.user_id, roles.slug").
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id IN ?", ids).
+		Order("roles.slug").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		out[r.UserID] = append(out[r.UserID], r.Slug)
+	}
+	return out, nil
+}
+
+func (s *UserAdminService) roleAssignments(ctx context.Context, userID uuid.UUID) ([]UserRoleListItem, error) {
+	type row struct {
+		ID        uuid.UUID
+		Slug      string
+		Name      string
+		IsSystem  bool
+		CreatedAt time.Time
+	}
+	var rows []row
+	err := s.db.WithContext(ctx).Table("user_roles").
+		Select("roles.id, roles.slug, roles.name, roles.is_system, user_roles.created_at").
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id = ?", userID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]UserRoleListItem, len(rows))
+	for i, r := range rows {
+		result[i] = UserRoleListItem{
+			ID:         r.ID,
+			Slug:       r.Slug,
+			Name:       r.Name,
+			IsSystem:   r.IsSystem,
+			AssignedAt: r.CreatedAt.UTC().Format("2006-01-02T15:04:05.000Z"),
+		}
+	}
+	return result, nil
+}
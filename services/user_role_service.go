@@ -67,10 +67,11 @@ func (s *UserRoleService) Assign(ctx context.Context, actorID, targetUserID, rol
 	if err := s.db.WithContext(ctx).Create(&ur).Error; err != nil {
 		return nil, err
 	}
+	invalidateUserPermissions(s.db.WithContext(ctx), targetUserID)
 	audit := models.RoleAuditLog{
 		ActorID:      actorID,
 		Action:       models.AuditActionAssign,
-		TargetUserID: targetUserID,
+		TargetUserID: &targetUserID,
 		RoleID:       roleID,
 		RoleSlug:     role.Slug,
 	}
@@ -88,33 +89,57 @@ func (s *UserRoleService) Assign(ctx context.Context, actorID, targetUserID, rol
 }
 
 // Unassign removes a role from a user. Returns ErrAssignmentNotFound if not assigned.
-// Appends an audit log entry.
+// Runs in a transaction that rejects the change when it would remove the last active admin
+// (ErrLastAdmin), leave nobody able to manage roles (ErrRolesManageLockout), or strip the
+// actor's own ability to manage roles (ErrSelfLockout). Appends an audit log entry.
 func (s *UserRoleService) Unassign(ctx context.Context, actorID, targetUserID, roleID uuid.UUID) error {
-	var ur models.UserRole
-	if err := s.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", targetUserID, roleID).First(&ur).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.ErrAssignmentNotFound
+	return permissionTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if err := lockRBAC(tx); err != nil {
+			return err
 		}
-		return err
-	}
-	var role models.Role
-	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.ErrRoleNotFound
+		var ur models.UserRole
+		if err := tx.Where("user_id = ? AND role_id = ?", targetUserID, roleID).First(&ur).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrAssignmentNotFound
+			}
+			return err
 		}
-		return err
-	}
-	if err := s.db.WithContext(ctx).Delete(&ur).Error; err != nil {
-		return err
-	}
-	audit := models.RoleAuditLog{
-		ActorID:      actorID,
-		Action:       models.AuditActionRemove,
-		TargetUserID: targetUserID,
-		RoleID:       roleID,
-		RoleSlug:     role.Slug,
-	}
-	return s.db.WithContext(ctx).Create(&audit).Error
+		var role models.Role
+		if err := tx.Where("id = ?", roleID).First(&role).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrRoleNotFound
+			}
+			return err
+		}
+		managing, err := roleGrantsRolesManage(tx, roleID, role.Slug)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&ur).Error; err != nil {
+			return err
+		}
+		invalidateUserPermissions(tx, targetUserID)
+		if err := checkRBACInvariants(tx, role.Slug == "admin", managing); err != nil {
+			return err
+		}
+		if managing && actorID == targetUserID {
+			still, err := canManageRoles(tx, actorID)
+			if err != nil {
+				return err
+			}
+			if !still {
+				return errors.ErrSelfLockout
+			}
+		}
+		audit := models.RoleAuditLog{
+			ActorID:      actorID,
+			Action:       models.AuditActionRemove,
+			TargetUserID: &targetUserID,
+			RoleID:       roleID,
+			RoleSlug:     role.Slug,
+		}
+		return tx.Create(&audit).Error
+	})
 }
 
 // UserRoleListItem is one role assigned to a user (for ListForUser).